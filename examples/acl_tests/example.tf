@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    tacl = {
+      source  = "lbrlabs/tacl"
+      version = "~> 1.0"
+    }
+  }
+}
+
+provider "tacl" {
+  endpoint = "http://tacl:8080"
+}
+
+resource "tacl_acl_test" "dev_ssh" {
+  src = "tag:dev"
+  accept = [
+    "tag:staging:22",
+  ]
+  deny = [
+    "tag:prod:22",
+  ]
+}
@@ -0,0 +1,37 @@
+
+terraform {
+  required_providers {
+    tacl = {
+      source  = "lbrlabs/tacl"
+      version = "~> 1.0"
+    }
+  }
+}
+
+provider "tacl" {
+  endpoint = "http://tacl:8080"
+}
+
+resource "tacl_grant" "web" {
+  src = [
+    "group:engineering",
+  ]
+  dst = [
+    "tag:web",
+  ]
+  ip = [
+    "tcp:443",
+  ]
+}
+
+resource "tacl_grant" "webui" {
+  src = [
+    "autogroup:admin",
+  ]
+  dst = [
+    "tag:router",
+  ]
+  app_json = jsonencode({
+    "tailscale.com/cap/webui" = [{}]
+  })
+}
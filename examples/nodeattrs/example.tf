@@ -29,3 +29,14 @@ resource "tacl_nodeattr" "example_app_connector" {
     ]
   })
 }
+
+# Same app connector without hand-written JSON
+resource "tacl_nodeattr" "example_app_connector_typed" {
+  app_connectors = [
+    {
+      name       = "github"
+      connectors = ["tag:router"]
+      domains    = ["github.com", "*.github.com"]
+    }
+  ]
+}
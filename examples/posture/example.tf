@@ -15,4 +15,6 @@ provider "tacl" {
 resource "tacl_posture" "example_posture" {
   name  = "latestMac"
   rules = ["node:os in ['macos']", "node:tsVersion >= '1.40'"]
-}
\ No newline at end of file
+}
+
+data "tacl_default_posture" "current" {}
@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    tacl = {
+      source  = "lbrlabs/tacl"
+      version = "~> 1.0"
+    }
+  }
+}
+
+provider "tacl" {
+  endpoint = "http://tacl:8080"
+}
+
+resource "tacl_tag_owners" "all" {
+  tag_owners = {
+    "parent" = ["group:engineering"]
+    "child"  = ["tag:parent"]
+  }
+}
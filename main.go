@@ -3,7 +3,10 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"runtime"
+	"runtime/debug"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/lbrlabs/tacl/terraform/provider"
@@ -20,14 +23,49 @@ func main() {
 	flag.Parse()
 
 	if *version {
-		log.Printf("terraform-provider-tacl %s", Version)
+		printVersion()
 		return
 	}
 
 	err := providerserver.Serve(context.Background(), provider.New, providerserver.ServeOpts{
 		Address: "registry.terraform.io/lbrlabs/tacl",
 	})
+	// Leave the tailnet and delete the ephemeral node's state (keys included)
+	provider.Shutdown()
 	if err != nil {
 		log.Fatal(err)
 	}
 }
+
+// printVersion => version block for bug reports
+func printVersion() {
+	fmt.Printf("terraform-provider-tacl %s\n", Version)
+	fmt.Printf("  go:        %s\n", runtime.Version())
+	fmt.Printf("  platform:  %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Printf("  framework: %s\n", moduleVersion("github.com/hashicorp/terraform-plugin-framework"))
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range info.Settings {
+			if s.Key == "vcs.revision" {
+				fmt.Printf("  commit:    %s\n", s.Value)
+			}
+		}
+	}
+}
+
+// moduleVersion => version of a dependency compiled into this binary
+func moduleVersion(path string) string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != path {
+			continue
+		}
+		if dep.Replace != nil {
+			return dep.Replace.Version
+		}
+		return dep.Version
+	}
+	return "unknown"
+}
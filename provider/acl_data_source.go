@@ -7,17 +7,22 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure interface compliance for Terraform Plugin Framework.
 var (
-	_ datasource.DataSource              = &aclDataSource{}
-	_ datasource.DataSourceWithConfigure = &aclDataSource{}
+	_ datasource.DataSource                   = &aclDataSource{}
+	_ datasource.DataSourceWithConfigure      = &aclDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &aclDataSource{}
 )
 
 // NewACLDataSource (new-style) => "tacl_acl" data source.
@@ -25,10 +30,12 @@ func NewACLDataSource() datasource.DataSource {
 	return &aclDataSource{}
 }
 
-// aclDataSource => for a single ACL looked up by stable UUID.
+// aclDataSource => for a single ACL looked up by stable UUID, or found by
+// `selector` among all ACLs when the UUID isn't known.
 type aclDataSource struct {
 	httpClient *http.Client
 	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
 }
 
 // aclDataSourceModel => mirrors the shape of the data source’s attributes in Terraform.
@@ -38,6 +45,17 @@ type aclDataSourceModel struct {
 	Src    []types.String `tfsdk:"src"`
 	Proto  types.String   `tfsdk:"proto"`
 	Dst    []types.String `tfsdk:"dst"`
+
+	Selector      *aclSelectorModel `tfsdk:"selector"`
+	FailIfMissing types.Bool        `tfsdk:"fail_if_missing"`
+}
+
+// aclSelectorModel => filters for a lookup without the UUID. Unset fields
+// match anything.
+type aclSelectorModel struct {
+	Action types.String   `tfsdk:"action"`
+	Src    []types.String `tfsdk:"src"`
+	Dst    []types.String `tfsdk:"dst"`
 }
 
 // extendedACLResponse => shape returned by GET /acls/:id
@@ -59,8 +77,9 @@ func (d *aclDataSource) Configure(ctx context.Context, req datasource.ConfigureR
 	if !ok {
 		return
 	}
-	d.httpClient = provider.httpClient
+	d.httpClient = provider.dsHTTPClient
 	d.endpoint = provider.endpoint
+	d.logMasks = provider.logMasks
 }
 
 // Metadata => tells Terraform our data source name: "tacl_acl".
@@ -71,11 +90,39 @@ func (d *aclDataSource) Metadata(ctx context.Context, req datasource.MetadataReq
 // Schema => defines the TF attributes for this data source.
 func (d *aclDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Data source for reading a single ACL entry by stable UUID.",
+		Description: "Data source for reading a single ACL entry by stable UUID, or by `selector` to find the UUID " +
+			"(e.g. for import).",
 		Attributes: map[string]schema.Attribute{
+			"fail_if_missing": schema.BoolAttribute{
+				Description: "If true, an `id` lookup that finds nothing is an error. Defaults to false: a warning, with attributes left null. " +
+					"A `selector` that matches nothing is always an error.",
+				Optional: true,
+			},
 			"id": schema.StringAttribute{
-				Description: "Stable UUID of the ACL entry in TACL.",
-				Required:    true,
+				Description: "Stable UUID of the ACL entry in TACL. Exactly one of `id` or `selector` must be set; " +
+					"with `selector`, this is the matched entry's UUID.",
+				Optional: true,
+				Computed: true,
+			},
+			"selector": schema.SingleNestedAttribute{
+				Description: "Find the entry among all ACLs (GET /acls) instead of by UUID. Exactly one entry must match.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"action": schema.StringAttribute{
+						Description: "Entry's action must equal this, e.g. 'accept'.",
+						Optional:    true,
+					},
+					"src": schema.ListAttribute{
+						Description: "Every value listed must be in the entry's src (order doesn't matter; the entry may have more).",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"dst": schema.ListAttribute{
+						Description: "Every value listed must be in the entry's dst (order doesn't matter; the entry may have more).",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+				},
 			},
 			"action": schema.StringAttribute{
 				Description: "ACL action, e.g. 'accept' or 'deny'.",
@@ -99,8 +146,25 @@ func (d *aclDataSource) Schema(ctx context.Context, req datasource.SchemaRequest
 	}
 }
 
-// Read => performs the HTTP GET /acls/<uuid> and sets the data source state.
+// ValidateConfig => exactly one of id / selector
+func (d *aclDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var id types.String
+	var selector types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("selector"), &selector)...)
+	if resp.Diagnostics.HasError() || id.IsUnknown() || selector.IsUnknown() {
+		return
+	}
+	if id.IsNull() == selector.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("id"), "Invalid ACL lookup",
+			"Exactly one of `id` or `selector` must be set.")
+	}
+}
+
+// Read => performs the HTTP GET /acls/<uuid> (or GET /acls + selector) and
+// sets the data source state.
 func (d *aclDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = maskLogCtx(ctx, d.logMasks)
 	// 1. Parse user input config from the data source "id" (UUID).
 	var data aclDataSourceModel
 	diags := req.Config.Get(ctx, &data)
@@ -109,6 +173,16 @@ func (d *aclDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		return
 	}
 
+	if data.Selector != nil {
+		fetched, ok := d.findBySelector(ctx, *data.Selector, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+		setACLDataSourceState(&data, fetched)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
 	uuid := data.ID.ValueString()
 	if uuid == "" {
 		resp.Diagnostics.AddError(
@@ -127,15 +201,11 @@ func (d *aclDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 
 	respBody, err := doACLDSRequest(ctx, d.httpClient, http.MethodGet, getURL, nil)
 	if err != nil {
-		if IsNotFound(err) {
-			// If the server returns 404, we can either set empty or return a warning.
-			resp.Diagnostics.AddWarning(
-				"ACL Not Found",
-				fmt.Sprintf("No ACL with UUID %q was found on the server.", uuid),
-			)
+		if isNotFound(err) {
+			addDSMissingDiagnostic(&resp.Diagnostics, data.FailIfMissing, "ACL", fmt.Sprintf("with id %q", uuid))
 			return
 		}
-		resp.Diagnostics.AddError("Error reading ACL data source", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Error reading ACL data source", err)
 		return
 	}
 
@@ -147,14 +217,81 @@ func (d *aclDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 	}
 
 	// 4. Populate Terraform state from the fetched data.
+	setACLDataSourceState(&data, fetched)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// findBySelector => the single ACL entry matching sel. Zero or several
+// matches are errors: a lookup meant to find a UUID must be unambiguous.
+func (d *aclDataSource) findBySelector(ctx context.Context, sel aclSelectorModel, diags *diag.Diagnostics) (extendedACLResponse, bool) {
+	listURL := fmt.Sprintf("%s/acls", d.endpoint)
+	tflog.Debug(ctx, "Finding ACL data source by selector", map[string]interface{}{
+		"url": listURL,
+	})
+
+	items, err := doListRequest(ctx, d.httpClient, listURL)
+	if err != nil && !isNotFound(err) {
+		addAPIErrorDiagnostic(diags, "Error listing ACLs", err)
+		return extendedACLResponse{}, false
+	}
+
+	var matches []extendedACLResponse
+	for i, raw := range items {
+		var entry extendedACLResponse
+		if e := json.Unmarshal(raw, &entry); e != nil {
+			diags.AddError("JSON parse error", fmt.Sprintf("ACL entry %d: %s", i, e))
+			return extendedACLResponse{}, false
+		}
+		if aclSelectorMatches(sel, entry) {
+			matches = append(matches, entry)
+		}
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0], true
+	case 0:
+		diags.AddAttributeError(path.Root("selector"), "No ACL matches selector",
+			fmt.Sprintf("None of the %d ACL entries in TACL match the selector.", len(items)))
+	default:
+		ids := make([]string, len(matches))
+		for i, m := range matches {
+			ids[i] = m.ID
+		}
+		diags.AddAttributeError(path.Root("selector"), "Multiple ACLs match selector",
+			fmt.Sprintf("%d ACL entries match (%s). Narrow the selector with more src/dst values or an action.",
+				len(matches), strings.Join(ids, ", ")))
+	}
+	return extendedACLResponse{}, false
+}
+
+// aclSelectorMatches => action equal (if set) and every selector src/dst
+// value present in the entry's src/dst
+func aclSelectorMatches(sel aclSelectorModel, entry extendedACLResponse) bool {
+	if !sel.Action.IsNull() && sel.Action.ValueString() != entry.Action {
+		return false
+	}
+	for _, s := range sel.Src {
+		if !slices.Contains(entry.Src, s.ValueString()) {
+			return false
+		}
+	}
+	for _, s := range sel.Dst {
+		if !slices.Contains(entry.Dst, s.ValueString()) {
+			return false
+		}
+	}
+	return true
+}
+
+func setACLDataSourceState(data *aclDataSourceModel, fetched extendedACLResponse) {
 	data.ID = types.StringValue(fetched.ID)
 	data.Action = types.StringValue(fetched.Action)
 	data.Src = toTerraformStringSlice(fetched.Src)
 	data.Proto = types.StringValue(fetched.Proto)
 	data.Dst = toTerraformStringSlice(fetched.Dst)
-
-	diags = resp.State.Set(ctx, &data)
-	resp.Diagnostics.Append(diags...)
 }
 
 // doACLDSRequest => minimal helper to do JSON-based HTTP for the data source.
@@ -187,7 +324,7 @@ func doACLDSRequest(ctx context.Context, client *http.Client, method, url string
 	// For 300+, return error with body.
 	if res.StatusCode >= 300 {
 		msg, _ := io.ReadAll(res.Body)
-		return nil, fmt.Errorf("TACL returned %d: %s", res.StatusCode, string(msg))
+		return nil, newAPIError(res, msg)
 	}
 
 	respBody, err := io.ReadAll(res.Body)
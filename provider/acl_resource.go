@@ -4,36 +4,79 @@ package provider
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-// TaclACLEntry => Represents the ACL portion (action, src, proto, dst).
+// TaclACLEntry => Represents the ACL portion (action, src, proto, dst, srcPosture).
 // On the server side, there's also an "id" string field in ExtendedACLEntry.
 type TaclACLEntry struct {
 	Action string   `json:"action"`          // e.g. "accept" or "deny"
 	Src    []string `json:"src"`             // e.g. ["tag:dev"]
 	Proto  string   `json:"proto,omitempty"` // optional
 	Dst    []string `json:"dst"`             // e.g. ["tag:prod:*","10.1.2.3/32:22"]
+
+	SrcPosture []string `json:"srcPosture,omitempty"` // e.g. ["posture:latestMac"]
+
+	Comment string `json:"comment,omitempty"` // optional, not every TACL version stores it
+	Order   *int64 `json:"order,omitempty"`   // requested place in the ACL list, if set
 }
 
 // TaclACLResponse => The server's ExtendedACLEntry shape: stable ID + the fields above
 type TaclACLResponse struct {
 	ID           string `json:"id"` // stable UUID from TACL
 	TaclACLEntry        // embed the rest
+
+	// Where the entry sits in the policy's ACL list, if TACL reports it.
+	// Some versions call it "index".
+	Position *int64 `json:"position,omitempty"`
+	Index    *int64 `json:"index,omitempty"`
+
+	auditFields // updatedBy/updatedAt, if TACL records them
+
+	raw  []byte // response body this was decoded from, for raw_json
+	etag string // response's ETag header, if any
+}
+
+// position => the entry's 0-based place in the ACL list, or null if unknown
+func (r TaclACLResponse) position() types.Int64 {
+	if r.Position != nil {
+		return types.Int64Value(*r.Position)
+	}
+	if r.Index != nil {
+		return types.Int64Value(*r.Index)
+	}
+	return types.Int64Null()
 }
 
 // Ensure interface compliance with Terraform plugin framework.
 var (
-	_ resource.Resource              = &aclResource{}
-	_ resource.ResourceWithConfigure = &aclResource{}
+	_ resource.Resource                   = &aclResource{}
+	_ resource.ResourceWithConfigure      = &aclResource{}
+	_ resource.ResourceWithImportState    = &aclResource{}
+	_ resource.ResourceWithModifyPlan     = &aclResource{}
+	_ resource.ResourceWithValidateConfig = &aclResource{}
 )
 
 // NewACLResource => constructor for "tacl_acl" resource
@@ -43,8 +86,16 @@ func NewACLResource() resource.Resource {
 
 // aclResource => main struct implementing Resource
 type aclResource struct {
-	httpClient *http.Client
-	endpoint   string
+	httpClient     *http.Client
+	endpoint       string
+	logMasks       []string // provider secrets, masked in tflog (see maskLogCtx)
+	validateOnPlan bool     // provider's validate_on_plan
+	normalizeLists bool     // provider's normalize_lists
+	readAfterWrite readAfterWrite
+	debug          bool // provider's debug => fill raw_json
+	noRetryJitter  bool // provider's disable_retry_jitter, for delete conflict backoff
+
+	preserveUnknownFields bool // provider's preserve_unknown_fields
 }
 
 // aclResourceModel => Terraform schema for storing the user's config + the ID
@@ -54,6 +105,41 @@ type aclResourceModel struct {
 	Src    []types.String `tfsdk:"src"`
 	Proto  types.String   `tfsdk:"proto"`
 	Dst    []types.String `tfsdk:"dst"`
+
+	SrcPosture []types.String `tfsdk:"src_posture"` // "posture:<name>" refs
+
+	Comment types.String `tfsdk:"comment"` // kept as configured if TACL drops it
+
+	ReplaceOnActionChange types.Bool `tfsdk:"replace_on_action_change"` // action change => replace, not update
+
+	DetectExternalChanges types.Bool `tfsdk:"detect_external_changes"` // warn on refresh when the server differs from state
+
+	Order types.Int64 `tfsdk:"order"` // sent to TACL so placement doesn't depend on apply order
+
+	// Group mode: several entries managed together. ID is then the first entry's ID.
+	Entries    []aclEntryBlockModel `tfsdk:"entry"`
+	EntryIDs   types.List           `tfsdk:"entry_ids"`   // server IDs, same order as Entries
+	EntryETags types.List           `tfsdk:"entry_etags"` // server ETags, same order as Entries
+
+	ETag        types.String `tfsdk:"etag"`         // server ETag, if TACL sends one
+	LastRead    types.String `tfsdk:"last_read"`    // RFC3339 timestamp of the last read that saw new content
+	ContentHash types.String `tfsdk:"content_hash"` // sha256 of normalized action/src/proto/dst
+	Position    types.Int64  `tfsdk:"position"`     // index in the ACL list, if TACL reports it
+
+	LastModifiedBy types.String `tfsdk:"last_modified_by"` // updatedBy, if TACL records it
+	LastModifiedAt types.String `tfsdk:"last_modified_at"` // updatedAt, if TACL records it
+
+	RawJSON types.String `tfsdk:"raw_json"` // last response body(ies), debug only
+}
+
+// aclEntryBlockModel => one `entry` block
+type aclEntryBlockModel struct {
+	Action types.String   `tfsdk:"action"`
+	Src    []types.String `tfsdk:"src"`
+	Proto  types.String   `tfsdk:"proto"`
+	Dst    []types.String `tfsdk:"dst"`
+
+	SrcPosture []types.String `tfsdk:"src_posture"`
 }
 
 //------------------------------------------------------------------------------
@@ -70,6 +156,13 @@ func (r *aclResource) Configure(ctx context.Context, req resource.ConfigureReque
 	}
 	r.httpClient = provider.httpClient
 	r.endpoint = provider.endpoint
+	r.logMasks = provider.logMasks
+	r.validateOnPlan = provider.validateOnPlan
+	r.normalizeLists = provider.normalizeLists
+	r.readAfterWrite = provider.readAfterWrite
+	r.debug = provider.debug
+	r.noRetryJitter = provider.noRetryJitter
+	r.preserveUnknownFields = provider.preserveUnknownFields
 }
 
 func (r *aclResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -83,26 +176,424 @@ func (r *aclResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 			"id": schema.StringAttribute{
 				Description: "TACL's stable UUID for this ACL entry.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"action": schema.StringAttribute{
-				Description: "The ACL action, e.g. 'accept' or 'deny'.",
-				Required:    true,
+				Description: "The ACL action, e.g. 'accept' or 'deny'. Required unless `entry` blocks are used.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					replaceOnActionChange(),
+				},
+			},
+			"detect_external_changes": schema.BoolAttribute{
+				Description: "If true, refresh warns when action/src/proto/dst/src_posture on the server no longer match state, " +
+					"or when `entry` entries were deleted, i.e. the ACL was edited outside Terraform. The plan still reverts " +
+					"such edits; the warning just makes them visible. Defaults to false.",
+				Optional: true,
+			},
+			"replace_on_action_change": schema.BoolAttribute{
+				Description: "If true, changing `action` (including inside `entry` blocks) destroys and recreates the entry " +
+					"instead of updating it in place, so the old rule never applies under the new action. Defaults to false.",
+				Optional: true,
 			},
 			"src": schema.ListAttribute{
-				Description: "List of source CIDRs, tags, or hostnames.",
-				Required:    true,
+				Description: "List of source CIDRs, tags, or hostnames. Required unless `entry` blocks are used.",
+				Optional:    true,
 				ElementType: types.StringType,
+				Validators: []validator.List{
+					nonEmptyListValidator{},
+				},
 			},
 			"proto": schema.StringAttribute{
-				Description: "Optional protocol, e.g. 'tcp'.",
-				Optional:    true,
+				Description: "Optional protocol: a name like 'tcp', 'udp', 'icmp' or 'sctp', or an IANA protocol number (0-255). A name and its number " +
+					"(e.g. 'tcp' and '6') are treated as equal, so TACL normalizing one to the other doesn't cause a diff.",
+				Optional: true,
+				Validators: []validator.String{
+					protoValidator{},
+				},
 			},
 			"dst": schema.ListAttribute{
-				Description: "List of destination CIDRs/tags. Possibly with :port.",
-				Required:    true,
+				Description: "List of destination CIDRs/tags. Possibly with :port; a port suffix must be `*` or ports/ranges " +
+					"like `80,443` or `8000-8100`. IPv6 addresses and prefixes must be bracketed, e.g. `[fd7a::1]:22`. Required unless `entry` blocks are used.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					nonEmptyListValidator{},
+					aclDstPortsValidator{},
+				},
+			},
+			"src_posture": schema.ListAttribute{
+				Description: srcPostureDescription,
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					postureRefsValidator{},
+				},
+			},
+			"comment": schema.StringAttribute{
+				Description: "Optional free-form comment. Sent to TACL; if the server doesn't store it, the configured value is kept in state.",
+				Optional:    true,
+			},
+			"order": schema.Int64Attribute{
+				Description: "Optional 0-based place for this entry in the ACL list, sent to TACL so entries end up in the same " +
+					"order no matter which tacl_acl Terraform applies first. With `entry` blocks, entries get order, order+1, and so on. " +
+					"Without it, plans that insert `entry` blocks before existing ones warn that the ACL order may change.",
+				Optional: true,
+			},
+			"etag": schema.StringAttribute{
+				Description: "ETag returned by TACL for this entry, sent as If-Match on update/delete. Empty if the server doesn't support it.",
+				Computed:    true,
+			},
+			"last_read": schema.StringAttribute{
+				Description: "RFC3339 timestamp of the last read from TACL that found this entry's content changed (or created it). " +
+					"Refreshes that find nothing new leave it alone, so it doesn't churn state.",
+				Computed: true,
+			},
+			"content_hash": schema.StringAttribute{
+				Description: "SHA256 over the normalized action/src/proto/dst (and src_posture, when set). Ordering of src/dst, and naming proto by name or number (e.g. 'tcp' or '6'), don't affect it.",
+				Computed:    true,
+			},
+			"position": schema.Int64Attribute{
+				Description: "0-based position of this entry in the policy's ACL list (evaluation order), as reported by TACL. " +
+					"With `entry` blocks, the first entry's position. Null if the server doesn't report it.",
+				Computed: true,
+			},
+			"last_modified_by": lastModifiedByAttribute(),
+			"last_modified_at": lastModifiedAtAttribute(),
+			"raw_json":         rawJSONAttribute(),
+			"entry_ids": schema.ListAttribute{
+				Description: "TACL IDs of the entries created from `entry` blocks, in block order. Null when the top-level attributes are used.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"entry_etags": schema.ListAttribute{
+				Description: "ETags returned by TACL for the entries in `entry_ids`, in the same order, each sent as If-Match when that entry " +
+					"is updated or deleted. Empty strings if the server doesn't send them. Null when the top-level attributes are used.",
+				Computed:    true,
 				ElementType: types.StringType,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"entry": schema.ListNestedBlock{
+				Description: "Manage several related ACL entries as one resource instead of using the top-level " +
+					"action/src/proto/dst. Each block becomes its own entry in TACL; `comment` applies to all of them.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"action": schema.StringAttribute{
+							Description: "The ACL action, e.g. 'accept' or 'deny'.",
+							Required:    true,
+							PlanModifiers: []planmodifier.String{
+								replaceOnActionChange(),
+							},
+						},
+						"src": schema.ListAttribute{
+							Description: "List of source CIDRs, tags, or hostnames.",
+							Required:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								nonEmptyListValidator{},
+							},
+						},
+						"proto": schema.StringAttribute{
+							Description: "Optional protocol: a name like 'tcp', 'udp', 'icmp' or 'sctp', or an IANA protocol number (0-255). A name and its number " +
+								"(e.g. 'tcp' and '6') are treated as equal, so TACL normalizing one to the other doesn't cause a diff.",
+							Optional: true,
+							Validators: []validator.String{
+								protoValidator{},
+							},
+						},
+						"dst": schema.ListAttribute{
+							Description: "List of destination CIDRs/tags. Possibly with :port; a port suffix must be `*` or " +
+								"ports/ranges like `80,443` or `8000-8100`. IPv6 addresses and prefixes must be bracketed, e.g. `[fd7a::1]:22`.",
+							Required:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								nonEmptyListValidator{},
+								aclDstPortsValidator{},
+							},
+						},
+						"src_posture": schema.ListAttribute{
+							Description: srcPostureDescription,
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								postureRefsValidator{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// srcPostureDescription => shared by the top-level and `entry` src_posture
+const srcPostureDescription = "Optional posture conditions the source device must meet, as 'posture:<name>' references " +
+	"(sent as srcPosture). With validate_on_plan, each name is checked against TACL's /postures at plan time."
+
+// replaceOnActionChange => RequiresReplace on action, gated by the resource's
+// replace_on_action_change
+func replaceOnActionChange() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			var replace types.Bool
+			resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("replace_on_action_change"), &replace)...)
+			resp.RequiresReplace = replace.ValueBool()
+		},
+		"Replaces the entry when action changes if replace_on_action_change is true.",
+		"Replaces the entry when `action` changes if `replace_on_action_change` is true.",
+	)
+}
+
+// ValidateConfig => either the top-level action/src/dst or `entry` blocks, not both
+func (r *aclResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var entries types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("entry"), &entries)...)
+	if resp.Diagnostics.HasError() || entries.IsUnknown() {
+		return
+	}
+	grouped := len(entries.Elements()) > 0
+
+	var action, proto types.String
+	var src, dst, srcPosture types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("action"), &action)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("proto"), &proto)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("src"), &src)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("dst"), &dst)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("src_posture"), &srcPosture)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var order types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("order"), &order)...)
+	if !order.IsNull() && !order.IsUnknown() && order.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("order"), "Invalid order",
+			fmt.Sprintf("order must be 0 or greater, got %d.", order.ValueInt64()))
+	}
+
+	isNull := map[string]bool{
+		"action":      action.IsNull(),
+		"src":         src.IsNull(),
+		"proto":       proto.IsNull(),
+		"dst":         dst.IsNull(),
+		"src_posture": srcPosture.IsNull(),
+	}
+	for _, name := range []string{"action", "src", "proto", "dst", "src_posture"} {
+		optional := name == "proto" || name == "src_posture"
+		switch {
+		case grouped && !isNull[name]:
+			resp.Diagnostics.AddAttributeError(path.Root(name), "Conflicting ACL configuration",
+				fmt.Sprintf("%q can't be set together with entry blocks; put it inside each entry instead.", name))
+		case !grouped && isNull[name] && !optional:
+			resp.Diagnostics.AddAttributeError(path.Root(name), "Missing ACL attribute",
+				fmt.Sprintf("%q is required unless entry blocks are used.", name))
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+// ModifyPlan => reorder warning, plus optional server-side dry run (provider
+// validate_on_plan)
+//------------------------------------------------------------------------------
+
+func (r *aclResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	warnACLReorder(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.validateOnPlan || r.httpClient == nil {
+		return
+	}
+	// destroy, or nothing changed => nothing to validate
+	if req.Plan.Raw.IsNull() || (!req.State.Raw.IsNull() && req.Plan.Raw.Equal(req.State.Raw)) {
+		return
+	}
+
+	var action, proto types.String
+	var src, dst, srcPosture types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("action"), &action)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("proto"), &proto)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("src"), &src)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("dst"), &dst)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("src_posture"), &srcPosture)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// referenced postures must exist, for entry blocks too
+	r.checkPostureRefs(ctx, req.Plan, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// entry blocks aren't dry-run; values that depend on other resources
+	// aren't known until apply
+	if action.IsNull() || action.IsUnknown() || proto.IsUnknown() || !listFullyKnown(src) || !listFullyKnown(dst) || !listFullyKnown(srcPosture) {
+		return
+	}
+
+	srcVals, err := listToGoStrings(ctx, src)
+	if err != nil {
+		resp.Diagnostics.AddError("Read src error", err.Error())
+		return
+	}
+	dstVals, err := listToGoStrings(ctx, dst)
+	if err != nil {
+		resp.Diagnostics.AddError("Read dst error", err.Error())
+		return
+	}
+	if r.normalizeLists {
+		srcVals, dstVals = normalizeStrings(srcVals), normalizeStrings(dstVals)
+	}
+
+	postureVals, err := listToGoStrings(ctx, srcPosture)
+	if err != nil {
+		resp.Diagnostics.AddError("Read src_posture error", err.Error())
+		return
+	}
+
+	entry := TaclACLEntry{
+		Action:     action.ValueString(),
+		Src:        srcVals,
+		Proto:      proto.ValueString(),
+		Dst:        dstVals,
+		SrcPosture: postureVals,
+	}
+
+	// POST /acls/validate => server checks the entry without storing it
+	postURL := fmt.Sprintf("%s/acls/validate", r.endpoint)
+	tflog.Debug(ctx, "Dry-run validating ACL", map[string]interface{}{
+		"url":     postURL,
+		"payload": redactForLog(entry),
+	})
+
+	_, err = doSingleObjectReq(ctx, r.httpClient, http.MethodPost, postURL, entry)
+	if err == nil {
+		return
+	}
+	var apiErr *APIError
+	if isNotFound(err) || (errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusMethodNotAllowed || apiErr.StatusCode == http.StatusNotImplemented)) {
+		resp.Diagnostics.AddWarning("ACL dry-run not supported",
+			"validate_on_plan is set, but the TACL server has no POST /acls/validate endpoint, so this ACL wasn't checked at plan time.")
+		return
+	}
+	addAPIErrorDiagnostic(&resp.Diagnostics, "ACL rejected by TACL dry-run", err)
+}
+
+// warnACLReorder => TACL appends new ACL entries, so creating a tacl_acl or
+// adding `entry` blocks after the existing ones leaves every rule in state
+// where it is. Warn only when a plan without `order` inserts blocks before
+// entries already in state, since those then shift down in the policy.
+// Removing or updating entries keeps the relative order of the rest.
+func warnACLReorder(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var order types.Int64
+	var planned, current types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("order"), &order)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("entry"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("entry"), &current)...)
+	if resp.Diagnostics.HasError() || !order.IsNull() || planned.IsUnknown() {
+		return
+	}
+
+	existing, next := current.Elements(), planned.Elements()
+	if len(next) <= len(existing) {
+		return
+	}
+	// appended => the entries in state are still a prefix of the plan
+	shifted := 0
+	for i, e := range existing {
+		if !e.Equal(next[i]) {
+			shifted = len(existing) - i
+			break
+		}
+	}
+	if shifted == 0 {
+		return
+	}
+
+	added := len(next) - len(existing)
+	what := "a new ACL entry"
+	if added > 1 {
+		what = fmt.Sprintf("%d new ACL entries", added)
+	}
+	moved := "1 existing entry"
+	if shifted > 1 {
+		moved = fmt.Sprintf("%d existing entries", shifted)
+	}
+	resp.Diagnostics.AddWarning("ACL order may change",
+		fmt.Sprintf("This plan inserts %s before %s without `order`, so those move down in the policy. "+
+			"Where rules sit relative to each other can change how the policy evaluates. "+
+			"Add new `entry` blocks at the end, or set `order` to pin placement.", what, moved))
+}
+
+// checkPostureRefs => warn about src_posture names TACL doesn't know. Only a
+// warning: the posture may be created by a tacl_posture in the same apply.
+func (r *aclResource) checkPostureRefs(ctx context.Context, plan tfsdk.Plan, resp *resource.ModifyPlanResponse) {
+	refs := map[string]path.Path{}
+	collect := func(p path.Path) {
+		var l types.List
+		resp.Diagnostics.Append(plan.GetAttribute(ctx, p, &l)...)
+		if l.IsNull() || l.IsUnknown() {
+			return
+		}
+		for i, elem := range l.Elements() {
+			s, ok := elem.(types.String)
+			if !ok || s.IsNull() || s.IsUnknown() {
+				continue
+			}
+			if _, seen := refs[s.ValueString()]; !seen {
+				refs[s.ValueString()] = p.AtListIndex(i)
+			}
+		}
+	}
+	collect(path.Root("src_posture"))
+
+	var entries types.List
+	resp.Diagnostics.Append(plan.GetAttribute(ctx, path.Root("entry"), &entries)...)
+	if !entries.IsUnknown() {
+		for i := range entries.Elements() {
+			collect(path.Root("entry").AtListIndex(i).AtName("src_posture"))
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	refNames := make([]string, 0, len(refs))
+	for ref := range refs {
+		refNames = append(refNames, ref)
+	}
+	sort.Strings(refNames)
+
+	for _, ref := range refNames {
+		name := strings.TrimPrefix(ref, "posture:")
+		getURL := fmt.Sprintf("%s/postures/%s", r.endpoint, name)
+		tflog.Debug(ctx, "Checking posture reference", map[string]interface{}{
+			"url":  getURL,
+			"name": name,
+		})
+		_, err := doPostureRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
+		if err == nil {
+			continue
+		}
+		if isNotFound(err) {
+			resp.Diagnostics.AddAttributeWarning(refs[ref], "Unknown posture",
+				fmt.Sprintf("TACL has no posture named %q. Ignore this if a tacl_posture in this configuration creates it; "+
+					"otherwise the ACL will reference a posture that doesn't exist.", name))
+			continue
+		}
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Check posture reference error", err)
+		return
 	}
 }
 
@@ -111,6 +602,7 @@ func (r *aclResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 //------------------------------------------------------------------------------
 
 func (r *aclResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	// 1. Read plan data
 	var plan aclResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -119,24 +611,55 @@ func (r *aclResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	// Group mode => one TACL entry per `entry` block
+	if len(plan.Entries) > 0 {
+		results, err := r.applyACLEntries(ctx, nil, nil, aclGroupPayload(plan, r.normalizeLists))
+		if err != nil {
+			addAPIErrorDiagnostic(&resp.Diagnostics, "Create ACL entries error", err)
+			if len(results) == 0 {
+				return
+			}
+			// keep what was created so it's tracked (tainted) rather than orphaned
+		} else {
+			for i := range results {
+				if fetched, err := r.awaitACL(ctx, results[i].ID); err != nil {
+					addReadAfterWriteWarning(&resp.Diagnostics, "ACL entry", err)
+				} else if fetched != nil {
+					results[i] = *fetched
+				}
+			}
+		}
+		setACLGroupState(&plan, results)
+		plan.RawJSON = rawJSONValue(r.debug, aclRawJSON(results, true))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
 	// 2. Convert to JSON for TACL => TaclACLEntry
 	payload := TaclACLEntry{
 		Action: plan.Action.ValueString(),
-		Src:    toStringSlice(plan.Src),
+		Src:    listPayload(plan.Src, r.normalizeLists),
 		Proto:  plan.Proto.ValueString(),
-		Dst:    toStringSlice(plan.Dst),
+		Dst:    listPayload(plan.Dst, r.normalizeLists),
+
+		SrcPosture: toGoStringSlice(plan.SrcPosture),
+		Comment:    plan.Comment.ValueString(),
+		Order:      aclOrder(plan.Order, 0),
 	}
 
-	// 3. POST /acls => create a new item with a server-generated ID
+	// 3. POST /acls => create a new item with a server-generated ID.
+	// The Idempotency-Key lets the server dedupe a retried POST.
 	postURL := fmt.Sprintf("%s/acls", r.endpoint)
+	ctx, idemKey := withIdempotencyKey(ctx)
 	tflog.Debug(ctx, "Creating ACL by ID", map[string]interface{}{
-		"url":     postURL,
-		"payload": payload,
+		"url":             postURL,
+		"payload":         redactForLog(payload),
+		"idempotency_key": idemKey,
 	})
 
-	body, err := doACLIDRequest(ctx, r.httpClient, http.MethodPost, postURL, payload)
+	body, etag, err := doACLIDRequestWithETag(ctx, r.httpClient, http.MethodPost, postURL, payload, "")
 	if err != nil {
-		resp.Diagnostics.AddError("Create ACL error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Create ACL error", err)
 		return
 	}
 
@@ -146,13 +669,18 @@ func (r *aclResource) Create(ctx context.Context, req resource.CreateRequest, re
 		resp.Diagnostics.AddError("Parse create response error", e.Error())
 		return
 	}
+	created.raw = body
 
-	// 5. Save ID + other fields to state
-	plan.ID = types.StringValue(created.ID)
-	plan.Action = types.StringValue(created.Action)
-	plan.Src = toTerraformStringSlice(created.Src)
-	plan.Proto = types.StringValue(created.Proto)
-	plan.Dst = toTerraformStringSlice(created.Dst)
+	// 5. Optionally confirm the new entry is readable (replication lag)
+	if fetched, err := r.awaitACL(ctx, created.ID); err != nil {
+		addReadAfterWriteWarning(&resp.Diagnostics, "ACL entry", err)
+	} else if fetched != nil {
+		created, etag = *fetched, fetched.etag
+	}
+
+	// 6. Save ID + other fields to state
+	setACLSingleState(&plan, created, etag)
+	plan.RawJSON = rawJSONValue(r.debug, created.raw)
 
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -163,6 +691,7 @@ func (r *aclResource) Create(ctx context.Context, req resource.CreateRequest, re
 //------------------------------------------------------------------------------
 
 func (r *aclResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	// 1. Pull current state (need the ID)
 	var state aclResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -178,6 +707,11 @@ func (r *aclResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	}
 	id := state.ID.ValueString()
 
+	if len(state.Entries) > 0 {
+		r.readACLGroup(ctx, state, resp)
+		return
+	}
+
 	// 3. GET /acls/:id
 	getURL := fmt.Sprintf("%s/acls/%s", r.endpoint, id)
 	tflog.Debug(ctx, "Reading ACL by ID", map[string]interface{}{
@@ -185,14 +719,14 @@ func (r *aclResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		"id":  id,
 	})
 
-	body, err := doACLIDRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
+	body, etag, err := doACLIDRequestWithETag(ctx, r.httpClient, http.MethodGet, getURL, nil, "")
 	if err != nil {
 		if isNotFound(err) {
 			// TACL says it's gone => remove from TF
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Read ACL error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read ACL error", err)
 		return
 	}
 
@@ -203,11 +737,15 @@ func (r *aclResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	}
 
 	// 4. Update state with fetched data
-	state.ID = types.StringValue(fetched.ID)
-	state.Action = types.StringValue(fetched.Action)
-	state.Src = toTerraformStringSlice(fetched.Src)
-	state.Proto = types.StringValue(fetched.Proto)
-	state.Dst = toTerraformStringSlice(fetched.Dst)
+	prior := aclRuleViewOf(state.Action, state.Src, state.Proto, state.Dst, state.SrcPosture)
+	setACLSingleState(&state, fetched, etag)
+	state.Order = orderOrPrior(fetched.Order, state.Order)
+	state.RawJSON = rawJSONValue(r.debug, body)
+
+	if state.DetectExternalChanges.ValueBool() {
+		current := aclRuleViewOf(state.Action, state.Src, state.Proto, state.Dst, state.SrcPosture)
+		addACLDriftWarning(&resp.Diagnostics, id, aclDrift("", prior, current))
+	}
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -218,6 +756,7 @@ func (r *aclResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 //------------------------------------------------------------------------------
 
 func (r *aclResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	// 1. Old state => preserve ID
 	var oldState aclResourceModel
 	diags := req.State.Get(ctx, &oldState)
@@ -243,33 +782,91 @@ func (r *aclResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
+	// Group mode (or switching to/from it) => reconcile entries by position
+	if len(plan.Entries) > 0 || len(oldState.Entries) > 0 {
+		oldIDs, oldETags := []string{id}, []string{oldState.ETag.ValueString()}
+		if len(oldState.Entries) > 0 {
+			var err error
+			if oldIDs, oldETags, err = aclEntryIDsAndETags(ctx, oldState); err != nil {
+				resp.Diagnostics.AddError("Read entry_ids error", err.Error())
+				return
+			}
+		}
+		entries := aclGroupPayload(plan, r.normalizeLists)
+		if len(plan.Entries) == 0 {
+			entries = []TaclACLEntry{{
+				Action: plan.Action.ValueString(),
+				Src:    listPayload(plan.Src, r.normalizeLists),
+				Proto:  plan.Proto.ValueString(),
+				Dst:    listPayload(plan.Dst, r.normalizeLists),
+
+				SrcPosture: toGoStringSlice(plan.SrcPosture),
+				Comment:    plan.Comment.ValueString(),
+				Order:      aclOrder(plan.Order, 0),
+			}}
+		}
+
+		results, err := r.applyACLEntries(ctx, oldIDs, oldETags, entries)
+		if err != nil {
+			if isPreconditionFailed(err) {
+				resp.Diagnostics.AddError("ACL changed outside Terraform",
+					fmt.Sprintf("An entry of ACL %q was modified on the server since it was last read. Run `terraform refresh` and re-plan before applying.\n\n%s", id, err))
+				return
+			}
+			addAPIErrorDiagnostic(&resp.Diagnostics, "Update ACL entries error", err)
+			return
+		}
+		if len(plan.Entries) > 0 {
+			setACLGroupState(&plan, results)
+		} else {
+			setACLSingleState(&plan, results[0], results[0].etag)
+		}
+		plan.RawJSON = rawJSONOnUpdate(plan.RawJSON, r.debug, aclRawJSON(results, len(plan.Entries) > 0))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
 	// 4. Convert plan to TaclACLEntry
 	input := TaclACLEntry{
 		Action: plan.Action.ValueString(),
-		Src:    toStringSlice(plan.Src),
+		Src:    listPayload(plan.Src, r.normalizeLists),
 		Proto:  plan.Proto.ValueString(),
-		Dst:    toStringSlice(plan.Dst),
+		Dst:    listPayload(plan.Dst, r.normalizeLists),
+
+		SrcPosture: toGoStringSlice(plan.SrcPosture),
+		Comment:    plan.Comment.ValueString(),
+		Order:      aclOrder(plan.Order, 0),
 	}
 
 	// 5. PUT /acls => { "id":"<uuid>", "entry": { ... } }
+	entry, err := r.entryWithUnknownFields(ctx, id, input)
+	if err != nil && !isNotFound(err) {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read ACL before update error", err)
+		return
+	}
 	payload := map[string]interface{}{
 		"id":    id,
-		"entry": input,
+		"entry": entry,
 	}
 	putURL := fmt.Sprintf("%s/acls", r.endpoint)
 	tflog.Debug(ctx, "Updating ACL by ID", map[string]interface{}{
 		"url":     putURL,
-		"payload": payload,
+		"payload": redactForLog(payload),
 	})
 
-	body, err := doACLIDRequest(ctx, r.httpClient, http.MethodPut, putURL, payload)
+	body, etag, err := doACLIDRequestWithETag(ctx, r.httpClient, http.MethodPut, putURL, payload, oldState.ETag.ValueString())
 	if err != nil {
 		if isNotFound(err) {
 			// TACL says it's gone => remove from state
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Update ACL error", err.Error())
+		if isPreconditionFailed(err) {
+			resp.Diagnostics.AddError("ACL changed outside Terraform",
+				fmt.Sprintf("ACL %q was modified on the server since it was last read. Run `terraform refresh` and re-plan before applying.", id))
+			return
+		}
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Update ACL error", err)
 		return
 	}
 
@@ -280,11 +877,8 @@ func (r *aclResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	}
 
 	// 6. Merge updated data back
-	plan.ID = types.StringValue(updated.ID)
-	plan.Action = types.StringValue(updated.Action)
-	plan.Src = toTerraformStringSlice(updated.Src)
-	plan.Proto = types.StringValue(updated.Proto)
-	plan.Dst = toTerraformStringSlice(updated.Dst)
+	setACLSingleState(&plan, updated, etag)
+	plan.RawJSON = rawJSONOnUpdate(plan.RawJSON, r.debug, body)
 
 	// 7. Save final
 	diags = resp.State.Set(ctx, &plan)
@@ -296,6 +890,7 @@ func (r *aclResource) Update(ctx context.Context, req resource.UpdateRequest, re
 //------------------------------------------------------------------------------
 
 func (r *aclResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data aclResourceModel
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -310,61 +905,661 @@ func (r *aclResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
+	if len(data.Entries) > 0 {
+		ids, etags, err := aclEntryIDsAndETags(ctx, data)
+		if err != nil {
+			resp.Diagnostics.AddError("Read entry_ids error", err.Error())
+			return
+		}
+		if _, err := r.applyACLEntries(ctx, ids, etags, nil); err != nil {
+			if isConflict(err) {
+				addACLConflictDiagnostic(&resp.Diagnostics, err)
+				return
+			}
+			if isPreconditionFailed(err) {
+				resp.Diagnostics.AddError("ACL changed outside Terraform",
+					fmt.Sprintf("An entry of ACL %q was modified on the server since it was last read. Run `terraform refresh` before destroying it.\n\n%s", id, err))
+				return
+			}
+			addAPIErrorDiagnostic(&resp.Diagnostics, "Delete ACL entries error", err)
+			return
+		}
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	// DELETE => /acls => { "id":"<uuid>" }
 	delURL := fmt.Sprintf("%s/acls", r.endpoint)
 	payload := map[string]string{"id": id}
 
 	tflog.Debug(ctx, "Deleting ACL by ID", map[string]interface{}{
 		"url":     delURL,
-		"payload": payload,
+		"payload": redactForLog(payload),
 	})
 
-	_, err := doACLIDRequest(ctx, r.httpClient, http.MethodDelete, delURL, payload)
-	if err != nil {
-		if isNotFound(err) {
-			// already gone
-		} else {
-			resp.Diagnostics.AddError("Delete ACL error", err.Error())
+	err := r.deleteACL(ctx, delURL, payload, data.ETag.ValueString())
+	switch {
+	case err == nil, isNotFound(err):
+		// deleted, or already gone
+	case isConflict(err):
+		addACLConflictDiagnostic(&resp.Diagnostics, err)
+		return
+	case isPreconditionFailed(err):
+		resp.Diagnostics.AddError("ACL changed outside Terraform",
+			fmt.Sprintf("ACL %q was modified on the server since it was last read. Run `terraform refresh` before destroying it.", id))
+		return
+	default:
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Delete ACL error", err)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+//------------------------------------------------------------------------------
+// 8) Import
+//------------------------------------------------------------------------------
+
+// ImportState => `terraform import tacl_acl.x <uuid>`, or a content selector
+// such as 'action=accept;src=tag:dev;dst=tag:prod:443' that's resolved to the
+// one ACL entry matching it. Imports a single entry, not an `entry` group.
+func (r *aclResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	id := req.ID
+	if strings.Contains(id, "=") {
+		sel, proto, err := parseACLImportSelector(id)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid ACL import selector", err.Error())
+			return
+		}
+		id = r.findImportMatch(ctx, sel, proto, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
 
-	resp.State.RemoveResource(ctx)
+// parseACLImportSelector => 'key=value;key=value' with keys action, proto,
+// src and dst. src/dst take a JSON array of strings, or a single bare value;
+// bare values aren't split on ',', since dst entries like 'tag:web:80,443'
+// contain one. Like the tacl_acl data source's selector, each listed value
+// must be present in the entry.
+func parseACLImportSelector(s string) (aclSelectorModel, *string, error) {
+	sel := aclSelectorModel{Action: types.StringNull()}
+	var proto *string
+	for _, part := range strings.Split(s, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return sel, nil, fmt.Errorf("%q is not key=value", part)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "action":
+			sel.Action = types.StringValue(value)
+		case "proto":
+			proto = &value
+		case "src", "dst":
+			raw := []string{value}
+			if strings.HasPrefix(value, "[") {
+				raw = nil
+				if err := json.Unmarshal([]byte(value), &raw); err != nil {
+					return sel, nil, fmt.Errorf("%s is not a JSON array of strings: %w", key, err)
+				}
+			}
+			var values []types.String
+			for _, v := range raw {
+				if v = strings.TrimSpace(v); v != "" {
+					values = append(values, types.StringValue(v))
+				}
+			}
+			if len(values) == 0 {
+				return sel, nil, fmt.Errorf("%s has no values", key)
+			}
+			if key == "src" {
+				sel.Src = values
+			} else {
+				sel.Dst = values
+			}
+		default:
+			return sel, nil, fmt.Errorf("unknown key %q (want action, proto, src or dst)", key)
+		}
+	}
+	return sel, proto, nil
+}
+
+// findImportMatch => ID of the single ACL entry matching sel (and proto, if
+// given). No match or several matches is an error.
+func (r *aclResource) findImportMatch(ctx context.Context, sel aclSelectorModel, proto *string, diags *diag.Diagnostics) string {
+	listURL := fmt.Sprintf("%s/acls", r.endpoint)
+	tflog.Debug(ctx, "Finding ACL to import by content", map[string]interface{}{
+		"url": listURL,
+	})
+
+	items, err := doListRequest(ctx, r.httpClient, listURL)
+	if err != nil && !isNotFound(err) {
+		addAPIErrorDiagnostic(diags, "Error listing ACLs", err)
+		return ""
+	}
+
+	var ids []string
+	for i, raw := range items {
+		var entry extendedACLResponse
+		if e := json.Unmarshal(raw, &entry); e != nil {
+			diags.AddError("JSON parse error", fmt.Sprintf("ACL entry %d: %s", i, e))
+			return ""
+		}
+		if proto != nil && !protosEqual(*proto, entry.Proto) {
+			continue
+		}
+		if aclSelectorMatches(sel, entry) {
+			ids = append(ids, entry.ID)
+		}
+	}
+
+	switch len(ids) {
+	case 1:
+		return ids[0]
+	case 0:
+		diags.AddError("No ACL matches import selector",
+			fmt.Sprintf("None of the %d ACL entries in TACL match the selector.", len(items)))
+	default:
+		diags.AddError("Multiple ACLs match import selector",
+			fmt.Sprintf("%d ACL entries match (%s). Narrow the selector, or import by ID.",
+				len(ids), strings.Join(ids, ", ")))
+	}
+	return ""
 }
 
 //------------------------------------------------------------------------------
 // Helper HTTP logic
 //------------------------------------------------------------------------------
 
-func doACLIDRequest(ctx context.Context, client *http.Client, method, url string, payload interface{}) ([]byte, error) {
+// aclGroupPayload => one TaclACLEntry per `entry` block; comment applies to all
+func aclGroupPayload(plan aclResourceModel, normalize bool) []TaclACLEntry {
+	entries := make([]TaclACLEntry, 0, len(plan.Entries))
+	for i, e := range plan.Entries {
+		entries = append(entries, TaclACLEntry{
+			Action: e.Action.ValueString(),
+			Src:    listPayload(e.Src, normalize),
+			Proto:  e.Proto.ValueString(),
+			Dst:    listPayload(e.Dst, normalize),
+
+			SrcPosture: toGoStringSlice(e.SrcPosture),
+			Comment:    plan.Comment.ValueString(),
+			Order:      aclOrder(plan.Order, i),
+		})
+	}
+	return entries
+}
+
+// applyACLEntries => make the entries at oldIDs look like entries, by
+// position: PUT over existing IDs, POST extra entries, DELETE leftover IDs.
+// oldETags[i] (when set) is sent as If-Match for oldIDs[i].
+// Returns the server's view of every entry written so far, even on error.
+func (r *aclResource) applyACLEntries(ctx context.Context, oldIDs, oldETags []string, entries []TaclACLEntry) ([]TaclACLResponse, error) {
+	url := fmt.Sprintf("%s/acls", r.endpoint)
+	var results []TaclACLResponse
+
+	for i, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		var body []byte
+		var etag string
+		var err error
+		if i < len(oldIDs) {
+			var merged interface{}
+			merged, err = r.entryWithUnknownFields(ctx, oldIDs[i], entry)
+			if err != nil && !isNotFound(err) {
+				return results, fmt.Errorf("entry %d: %w", i, err)
+			}
+			payload := map[string]interface{}{"id": oldIDs[i], "entry": merged}
+			tflog.Debug(ctx, "Updating ACL entry", map[string]interface{}{
+				"url":     url,
+				"payload": redactForLog(payload),
+			})
+			body, etag, err = doACLIDRequestWithETag(ctx, r.httpClient, http.MethodPut, url, payload, etagAt(oldETags, i))
+		}
+		if i >= len(oldIDs) || isNotFound(err) {
+			// new entry, or the old one was deleted outside Terraform
+			postCtx, idemKey := withIdempotencyKey(ctx)
+			tflog.Debug(postCtx, "Creating ACL entry", map[string]interface{}{
+				"url":             url,
+				"payload":         redactForLog(entry),
+				"idempotency_key": idemKey,
+			})
+			body, etag, err = doACLIDRequestWithETag(postCtx, r.httpClient, http.MethodPost, url, entry, "")
+		}
+		if err != nil {
+			return results, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		var res TaclACLResponse
+		if e := json.Unmarshal(body, &res); e != nil {
+			return results, fmt.Errorf("entry %d: parse response: %w", i, e)
+		}
+		res.raw, res.etag = body, etag
+		results = append(results, res)
+	}
+
+	for i := len(entries); i < len(oldIDs); i++ {
+		payload := map[string]string{"id": oldIDs[i]}
+		tflog.Debug(ctx, "Deleting ACL entry", map[string]interface{}{
+			"url":     url,
+			"payload": redactForLog(payload),
+		})
+		if err := r.deleteACL(ctx, url, payload, etagAt(oldETags, i)); err != nil && !isNotFound(err) {
+			return results, fmt.Errorf("delete entry %q: %w", oldIDs[i], err)
+		}
+	}
+	return results, nil
+}
+
+// etagAt => etags[i], or "" (no If-Match) when there isn't one
+func etagAt(etags []string, i int) string {
+	if i < len(etags) {
+		return etags[i]
+	}
+	return ""
+}
+
+// aclEntryIDsAndETags => group-mode entry_ids and entry_etags from state.
+// State written before entry_etags existed has none, so nothing is sent as
+// If-Match until the next refresh fills them in.
+func aclEntryIDsAndETags(ctx context.Context, m aclResourceModel) ([]string, []string, error) {
+	ids, err := listToGoStrings(ctx, m.EntryIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if m.EntryETags.IsNull() || m.EntryETags.IsUnknown() {
+		return ids, nil, nil
+	}
+	etags, err := listToGoStrings(ctx, m.EntryETags)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ids, etags, nil
+}
+
+// aclDeleteConflictAttempts => how many times a DELETE answered with 409 is
+// tried in total. In a large destroy, whatever still references the entry is
+// often being deleted in parallel, so the conflict clears within seconds.
+const aclDeleteConflictAttempts = 4
+
+// deleteACL => DELETE /acls, retrying a 409 with backoff before giving up
+func (r *aclResource) deleteACL(ctx context.Context, url string, payload interface{}, ifMatch string) error {
+	for attempt := 1; ; attempt++ {
+		_, _, err := doACLIDRequestWithETag(ctx, r.httpClient, http.MethodDelete, url, payload, ifMatch)
+		if err == nil || !isConflict(err) || attempt == aclDeleteConflictAttempts {
+			return err
+		}
+		wait := retryBackoff(attempt, nil, !r.noRetryJitter)
+		tflog.Debug(ctx, "ACL delete conflicted, retrying", map[string]interface{}{
+			"url":     url,
+			"attempt": attempt,
+			"wait":    wait.String(),
+		})
+		if err := sleepCtx(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// addACLConflictDiagnostic => a clear, actionable error for a 409 on delete
+func addACLConflictDiagnostic(diags *diag.Diagnostics, err error) {
+	diags.AddError("ACL entry is still referenced",
+		fmt.Sprintf("TACL refused to delete the ACL entry with 409 Conflict after %d attempts, usually because something "+
+			"still references it. If the referencing objects are being destroyed in the same run, running the destroy "+
+			"again will finish it; entries already deleted are skipped. Otherwise remove the reference first.\n\n%s",
+			aclDeleteConflictAttempts, err))
+}
+
+// entryWithUnknownFields => entry as the PUT body. With preserve_unknown_fields,
+// fields the server has on id that TaclACLEntry doesn't model are carried over.
+// A NotFoundError is returned alongside the bare entry so callers can fall
+// through to their own 404 handling.
+func (r *aclResource) entryWithUnknownFields(ctx context.Context, id string, entry TaclACLEntry) (interface{}, error) {
+	if !r.preserveUnknownFields {
+		return entry, nil
+	}
+	getURL := fmt.Sprintf("%s/acls/%s", r.endpoint, id)
+	current, _, err := doACLIDRequestWithETag(ctx, r.httpClient, http.MethodGet, getURL, nil, "")
+	if err != nil {
+		return entry, err
+	}
+	return withUnknownFields(current, entry, jsonFieldNames(TaclACLResponse{}))
+}
+
+// readACLGroup => GET every entry ID in state. Entries deleted outside
+// Terraform drop out, so the next plan recreates them.
+func (r *aclResource) readACLGroup(ctx context.Context, state aclResourceModel, resp *resource.ReadResponse) {
+	ids, err := listToGoStrings(ctx, state.EntryIDs)
+	if err != nil {
+		resp.Diagnostics.AddError("Read entry_ids error", err.Error())
+		return
+	}
+
+	var results []TaclACLResponse
+	var kept []aclEntryBlockModel
+	for i, id := range ids {
+		getURL := fmt.Sprintf("%s/acls/%s", r.endpoint, id)
+		tflog.Debug(ctx, "Reading ACL entry by ID", map[string]interface{}{
+			"url": getURL,
+			"id":  id,
+		})
+		body, etag, err := doACLIDRequestWithETag(ctx, r.httpClient, http.MethodGet, getURL, nil, "")
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			addAPIErrorDiagnostic(&resp.Diagnostics, "Read ACL error", err)
+			return
+		}
+		var res TaclACLResponse
+		if e := json.Unmarshal(body, &res); e != nil {
+			resp.Diagnostics.AddError("Parse read response error", e.Error())
+			return
+		}
+		res.raw, res.etag = body, etag
+		results = append(results, res)
+		if i < len(state.Entries) {
+			kept = append(kept, state.Entries[i])
+		}
+	}
+
+	if len(results) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Entries = kept
+	setACLGroupState(&state, results)
+	state.Order = orderOrPrior(results[0].Order, state.Order)
+	state.RawJSON = rawJSONValue(r.debug, aclRawJSON(results, true))
+
+	if state.DetectExternalChanges.ValueBool() {
+		var changes []string
+		if missing := len(ids) - len(results); missing > 0 {
+			changes = append(changes, fmt.Sprintf("%d of %d entries were deleted", missing, len(ids)))
+		}
+		for i, e := range state.Entries {
+			if i >= len(kept) {
+				break
+			}
+			changes = append(changes, aclDrift(fmt.Sprintf("entry[%d].", i),
+				aclRuleViewOf(kept[i].Action, kept[i].Src, kept[i].Proto, kept[i].Dst, kept[i].SrcPosture),
+				aclRuleViewOf(e.Action, e.Src, e.Proto, e.Dst, e.SrcPosture))...)
+		}
+		addACLDriftWarning(&resp.Diagnostics, state.ID.ValueString(), changes)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// aclRuleView => the rule fields of a single entry or `entry` block, as plain
+// Go values for drift comparison
+type aclRuleView struct {
+	action, proto string
+	src, dst      []string
+	srcPosture    []string
+	hasAction     bool // false for state with no rule yet, e.g. just imported
+}
+
+func aclRuleViewOf(action types.String, src []types.String, proto types.String, dst, srcPosture []types.String) aclRuleView {
+	return aclRuleView{
+		action:     action.ValueString(),
+		proto:      proto.ValueString(),
+		src:        toGoStringSlice(src),
+		dst:        toGoStringSlice(dst),
+		srcPosture: toGoStringSlice(srcPosture),
+		hasAction:  !action.IsNull(),
+	}
+}
+
+// aclDrift => "field: old => new" for each rule field that differs. State
+// values are kept as configured when the server's are equivalent (see
+// normalizedOrPrior), so any difference left is a real edit. A prior without
+// an action (just imported) has nothing to compare against.
+func aclDrift(prefix string, prior, current aclRuleView) []string {
+	if !prior.hasAction {
+		return nil
+	}
+	var changes []string
+	if prior.action != current.action {
+		changes = append(changes, fmt.Sprintf("%saction: %q => %q", prefix, prior.action, current.action))
+	}
+	if prior.proto != current.proto {
+		changes = append(changes, fmt.Sprintf("%sproto: %q => %q", prefix, prior.proto, current.proto))
+	}
+	if !slices.Equal(prior.src, current.src) {
+		changes = append(changes, fmt.Sprintf("%ssrc: %q => %q", prefix, prior.src, current.src))
+	}
+	if !slices.Equal(prior.dst, current.dst) {
+		changes = append(changes, fmt.Sprintf("%sdst: %q => %q", prefix, prior.dst, current.dst))
+	}
+	if !slices.Equal(prior.srcPosture, current.srcPosture) {
+		changes = append(changes, fmt.Sprintf("%ssrc_posture: %q => %q", prefix, prior.srcPosture, current.srcPosture))
+	}
+	return changes
+}
+
+// addACLDriftWarning => one warning listing every out-of-band change, if any
+func addACLDriftWarning(diags *diag.Diagnostics, id string, changes []string) {
+	if len(changes) == 0 {
+		return
+	}
+	diags.AddWarning("ACL changed outside Terraform",
+		fmt.Sprintf("ACL %q was edited on the server since the last refresh:\n  %s\n\nThe next apply reverts this unless the configuration is updated to match.",
+			id, strings.Join(changes, "\n  ")))
+}
+
+// awaitACL => GET a just-created entry per the provider's read_after_write
+// settings. Returns nil when polling is off.
+func (r *aclResource) awaitACL(ctx context.Context, id string) (*TaclACLResponse, error) {
+	getURL := fmt.Sprintf("%s/acls/%s", r.endpoint, id)
+	var etag string
+	body, err := awaitCreated(ctx, r.readAfterWrite, func() ([]byte, error) {
+		b, e, err := doACLIDRequestWithETag(ctx, r.httpClient, http.MethodGet, getURL, nil, "")
+		etag = e
+		return b, err
+	})
+	if err != nil || body == nil {
+		return nil, err
+	}
+	var fetched TaclACLResponse
+	if err := json.Unmarshal(body, &fetched); err != nil {
+		return nil, fmt.Errorf("parse read response: %w", err)
+	}
+	fetched.raw, fetched.etag = body, etag
+	return &fetched, nil
+}
+
+// setACLGroupState => group-mode state from the server's entries (in block order)
+func setACLGroupState(m *aclResourceModel, results []TaclACLResponse) {
+	ids := make([]string, 0, len(results))
+	etags := make([]string, 0, len(results))
+	entries := make([]aclEntryBlockModel, 0, len(results))
+	hashes := make([]string, 0, len(results))
+	for i, res := range results {
+		var prior aclEntryBlockModel
+		if i < len(m.Entries) {
+			prior = m.Entries[i]
+		}
+		ids = append(ids, res.ID)
+		etags = append(etags, res.etag)
+		entries = append(entries, aclEntryBlockModel{
+			Action: types.StringValue(res.Action),
+			Src:    normalizedOrPrior(res.Src, prior.Src),
+			Proto:  protoOrPrior(res.Proto, prior.Proto),
+			Dst:    normalizedOrPrior(res.Dst, prior.Dst),
+
+			SrcPosture: optionalListOrPrior(res.SrcPosture, prior.SrcPosture),
+		})
+		hashes = append(hashes, aclContentHash(res.TaclACLEntry))
+	}
+
+	m.ID = types.StringValue(ids[0])
+	m.Action = types.StringNull()
+	m.Src = nil
+	m.Proto = types.StringNull()
+	m.Dst = nil
+	m.SrcPosture = nil
+	m.Comment = commentOrPrior(results[0].Comment, m.Comment)
+	m.Entries = entries
+	m.EntryIDs, _ = goStringsToList(ids) // plain strings, can't fail
+	m.EntryETags, _ = goStringsToList(etags)
+	setACLContentHash(m, aclContentHashOfHashes(hashes))
+	m.Position = results[0].position()
+	m.LastModifiedBy, m.LastModifiedAt = results[0].auditValues()
+	m.ETag = types.StringValue("")
+}
+
+// setACLSingleState => single-entry state from the server's entry
+func setACLSingleState(m *aclResourceModel, res TaclACLResponse, etag string) {
+	m.ID = types.StringValue(res.ID)
+	m.Action = types.StringValue(res.Action)
+	m.Src = normalizedOrPrior(res.Src, m.Src)
+	m.Proto = protoOrPrior(res.Proto, m.Proto)
+	m.Dst = normalizedOrPrior(res.Dst, m.Dst)
+	m.SrcPosture = optionalListOrPrior(res.SrcPosture, m.SrcPosture)
+	m.Comment = commentOrPrior(res.Comment, m.Comment)
+	m.Entries = []aclEntryBlockModel{}
+	m.EntryIDs = types.ListNull(types.StringType)
+	m.EntryETags = types.ListNull(types.StringType)
+	setACLContentHash(m, aclContentHash(res.TaclACLEntry))
+	m.Position = res.position()
+	m.LastModifiedBy, m.LastModifiedAt = res.auditValues()
+	m.ETag = types.StringValue(etag)
+}
+
+// setACLContentHash => content_hash, bumping last_read only when the content
+// changed (or there's no last_read yet), so an unchanged refresh is a no-op
+func setACLContentHash(m *aclResourceModel, hash string) {
+	if m.LastRead.IsNull() || m.LastRead.IsUnknown() || m.ContentHash.ValueString() != hash {
+		m.LastRead = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	}
+	m.ContentHash = types.StringValue(hash)
+}
+
+// aclOrder => order to send for the entry at offset within the resource, or
+// nil (server appends) when order isn't set
+func aclOrder(order types.Int64, offset int) *int64 {
+	if order.IsNull() || order.IsUnknown() {
+		return nil
+	}
+	o := order.ValueInt64() + int64(offset)
+	return &o
+}
+
+// orderOrPrior => order as TACL reports it on read, so a moved entry shows up
+// as drift; servers that don't echo it keep the configured value. order is
+// Optional only, so when it isn't set the server's value is ignored: echoing
+// it would be a permanent diff. Create and Update keep the planned value
+// as-is: the effective place is `position`.
+func orderOrPrior(server *int64, prior types.Int64) types.Int64 {
+	if server == nil || prior.IsNull() {
+		return prior
+	}
+	return types.Int64Value(*server)
+}
+
+// aclRawJSON => the entries' response bodies; a JSON array in group mode
+func aclRawJSON(results []TaclACLResponse, grouped bool) []byte {
+	if !grouped && len(results) == 1 {
+		return results[0].raw
+	}
+	raws := make([][]byte, 0, len(results))
+	for _, res := range results {
+		raws = append(raws, bytes.TrimSpace(res.raw))
+	}
+	return append(append([]byte("["), bytes.Join(raws, []byte(","))...), ']')
+}
+
+// aclContentHashOfHashes => one hash for a group, over its entries' hashes in order
+func aclContentHashOfHashes(hashes []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(hashes, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// aclContentHash => hex sha256 over action/src/proto/dst, with src/dst sorted
+// so reordering them doesn't change the hash. proto is hashed as its IANA
+// number (see protoNumber), so "tcp" and "6" hash alike. srcPosture is only
+// included when set, so entries without it keep the hash they had before it
+// existed.
+func aclContentHash(entry TaclACLEntry) string {
+	src := append([]string(nil), entry.Src...)
+	dst := append([]string(nil), entry.Dst...)
+	sort.Strings(src)
+	sort.Strings(dst)
+
+	proto := entry.Proto
+	if n, ok := protoNumber(proto); ok {
+		proto = strconv.Itoa(n)
+	}
+
+	normalized := strings.Join([]string{
+		"action=" + entry.Action,
+		"src=" + strings.Join(src, ","),
+		"proto=" + proto,
+		"dst=" + strings.Join(dst, ","),
+	}, "\n")
+	if len(entry.SrcPosture) > 0 {
+		posture := append([]string(nil), entry.SrcPosture...)
+		sort.Strings(posture)
+		normalized += "\nsrcPosture=" + strings.Join(posture, ",")
+	}
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// doACLIDRequestWithETag => JSON request against /acls. Sends If-Match when
+// ifMatch is set and returns the response's ETag header (if any).
+func doACLIDRequestWithETag(ctx context.Context, client *http.Client, method, url string, payload interface{}, ifMatch string) ([]byte, string, error) {
 	var body io.Reader
 	if payload != nil {
 		b, err := json.Marshal(payload)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal payload: %w", err)
+			return nil, "", fmt.Errorf("failed to marshal payload: %w", err)
 		}
 		body = bytes.NewBuffer(b)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
-		return nil, fmt.Errorf("request creation error: %w", err)
+		return nil, "", fmt.Errorf("request creation error: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	setIdempotencyKey(ctx, req)
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("ACL ID request error: %w", err)
+		return nil, "", fmt.Errorf("ACL ID request error: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 404 {
-		return nil, &NotFoundError{Message: "ACL not found"}
+		return nil, "", &NotFoundError{Message: "ACL not found"}
+	}
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return nil, "", &PreconditionFailedError{Message: "ACL was modified since it was last read"}
 	}
 	if resp.StatusCode >= 300 {
 		msg, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("TACL returned %d: %s", resp.StatusCode, string(msg))
+		return nil, "", newAPIError(resp, msg)
 	}
 
-	return io.ReadAll(resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return respBody, resp.Header.Get("ETag"), nil
 }
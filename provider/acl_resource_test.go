@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestACLResource_CRUD(t *testing.T) {
+	srv := newFakeTACL(t)
+	r := newTestResource(t, newTestProvider(t, srv, nil), NewACLResource())
+
+	state, diags := r.create(map[string]tftypes.Value{
+		"action": tfString("accept"),
+		"src":    tfStrings(listOf, "tag:dev"),
+		"dst":    tfStrings(listOf, "tag:prod:443"),
+	})
+	requireNoErrors(t, diags)
+	id := stateString(t, state, "id")
+	if id == "" || len(srv.byID["acls"]) != 1 {
+		t.Fatalf("id = %q, server has %d ACLs", id, len(srv.byID["acls"]))
+	}
+	if got := stateString(t, state, "etag"); got != `"v1"` {
+		t.Fatalf("etag = %q", got)
+	}
+
+	state, diags = r.read(state)
+	requireNoErrors(t, diags)
+	if got := stateString(t, state, "id"); got != id {
+		t.Fatalf("id after read = %q, want %q", got, id)
+	}
+
+	state, diags = r.update(state, map[string]tftypes.Value{
+		"action": tfString("accept"),
+		"src":    tfStrings(listOf, "tag:dev"),
+		"dst":    tfStrings(listOf, "tag:prod:443", "tag:prod:80"),
+	})
+	requireNoErrors(t, diags)
+	if got := srv.byID["acls"][0]["dst"]; fmt.Sprint(got) != "[tag:prod:443 tag:prod:80]" {
+		t.Fatalf("server dst after update = %v", got)
+	}
+	if got := stateString(t, state, "etag"); got != `"v2"` {
+		t.Fatalf("etag after update = %q", got)
+	}
+
+	gone, diags := r.delete(state)
+	requireNoErrors(t, diags)
+	if !gone || len(srv.byID["acls"]) != 0 {
+		t.Fatalf("delete left state=%v server=%v", !gone, srv.byID["acls"])
+	}
+}
+
+func TestACLResource_UpdateStaleETag(t *testing.T) {
+	srv := newFakeTACL(t)
+	r := newTestResource(t, newTestProvider(t, srv, nil), NewACLResource())
+
+	vals := map[string]tftypes.Value{
+		"action": tfString("accept"),
+		"src":    tfStrings(listOf, "tag:dev"),
+		"dst":    tfStrings(listOf, "tag:prod:443"),
+	}
+	state, diags := r.create(vals)
+	requireNoErrors(t, diags)
+
+	srv.etags[stateString(t, state, "id")]++ // edited outside Terraform
+
+	vals["dst"] = tfStrings(listOf, "tag:prod:80")
+	if _, diags = r.update(state, vals); !diags.HasError() {
+		t.Fatal("update with a stale ETag succeeded")
+	}
+	if got := diags.Errors()[0].Summary(); got != "ACL changed outside Terraform" {
+		t.Fatalf("error = %q", got)
+	}
+}
+
+func TestAccACLResource(t *testing.T) {
+	srv := newFakeTACL(t)
+	config := func(dst string) string {
+		return fmt.Sprintf(`
+provider "tacl" {
+  endpoint = %q
+}
+
+resource "tacl_acl" "web" {
+  action = "accept"
+  src    = ["tag:dev"]
+  dst    = [%q]
+}
+`, srv.URL, dst)
+	}
+
+	tfresource.Test(t, tfresource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []tfresource.TestStep{
+			{
+				Config: config("tag:prod:443"),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttrSet("tacl_acl.web", "id"),
+					tfresource.TestCheckResourceAttr("tacl_acl.web", "dst.0", "tag:prod:443"),
+				),
+			},
+			{
+				Config: config("tag:prod:80"),
+				Check:  tfresource.TestCheckResourceAttr("tacl_acl.web", "dst.0", "tag:prod:80"),
+			},
+		},
+	})
+}
+
+// TestWarnACLReorder => the order warning fires only when entry blocks are
+// inserted before entries already in state and `order` isn't set.
+func TestWarnACLReorder(t *testing.T) {
+	entry := func(dst string) map[string]interface{} {
+		return map[string]interface{}{"action": "accept", "src": []string{"tag:dev"}, "dst": []string{dst}}
+	}
+	entries := func(dsts ...string) []interface{} {
+		out := make([]interface{}, len(dsts))
+		for i, d := range dsts {
+			out[i] = entry(d)
+		}
+		return out
+	}
+	tests := []struct {
+		name     string
+		prior    map[string]interface{} // nil => create
+		planned  map[string]interface{} // nil => destroy
+		wantWarn string                 // "" => no warning
+	}{
+		{name: "create", planned: map[string]interface{}{"action": "accept", "src": []string{"tag:dev"}, "dst": []string{"tag:prod:443"}}},
+		{name: "create with entries", planned: map[string]interface{}{"entry": entries("tag:a:22", "tag:b:22")}},
+		{name: "append", prior: map[string]interface{}{"entry": entries("tag:a:22")}, planned: map[string]interface{}{"entry": entries("tag:a:22", "tag:b:22", "tag:c:22")}},
+		{name: "update in place", prior: map[string]interface{}{"entry": entries("tag:a:22", "tag:b:22")}, planned: map[string]interface{}{"entry": entries("tag:a:22", "tag:x:22")}},
+		{name: "remove", prior: map[string]interface{}{"entry": entries("tag:a:22", "tag:b:22")}, planned: map[string]interface{}{"entry": entries("tag:b:22")}},
+		{name: "destroy", prior: map[string]interface{}{"entry": entries("tag:a:22")}},
+		{
+			name:     "insert at the front",
+			prior:    map[string]interface{}{"entry": entries("tag:a:22", "tag:b:22")},
+			planned:  map[string]interface{}{"entry": entries("tag:x:22", "tag:a:22", "tag:b:22")},
+			wantWarn: "inserts a new ACL entry before 2 existing entries",
+		},
+		{
+			name:     "insert in the middle",
+			prior:    map[string]interface{}{"entry": entries("tag:a:22", "tag:b:22")},
+			planned:  map[string]interface{}{"entry": entries("tag:a:22", "tag:x:22", "tag:y:22", "tag:b:22")},
+			wantWarn: "inserts 2 new ACL entries before 1 existing entry",
+		},
+		{
+			name:    "insert with order",
+			prior:   map[string]interface{}{"entry": entries("tag:a:22"), "order": 3},
+			planned: map[string]interface{}{"entry": entries("tag:x:22", "tag:a:22"), "order": 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestResource(t, &taclProvider{}, NewACLResource())
+			req := resource.ModifyPlanRequest{
+				State: r.emptyState(),
+				Plan:  tfsdk.Plan{Schema: r.schema, Raw: tftypes.NewValue(r.schema.Type().TerraformType(context.Background()), nil)},
+			}
+			if tt.prior != nil {
+				req.State.Raw = r.plan(r.values(tt.prior)).Raw
+			}
+			if tt.planned != nil {
+				req.Plan = r.plan(r.values(tt.planned))
+			}
+			resp := resource.ModifyPlanResponse{Plan: req.Plan}
+			warnACLReorder(context.Background(), req, &resp)
+			requireNoErrors(t, resp.Diagnostics)
+
+			warnings := resp.Diagnostics.Warnings()
+			if tt.wantWarn == "" {
+				if len(warnings) != 0 {
+					t.Fatalf("warnings = %v, want none", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0].Detail(), tt.wantWarn) {
+				t.Fatalf("warnings = %v, want one containing %q", warnings, tt.wantWarn)
+			}
+		})
+	}
+}
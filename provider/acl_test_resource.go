@@ -0,0 +1,359 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// TaclACLTest => one policy self-test as TACL stores it under /tests: the
+// policy is rejected if src can't reach an accept destination or can reach a
+// deny destination.
+type TaclACLTest struct {
+	Src    string   `json:"src"`
+	Proto  string   `json:"proto,omitempty"`
+	Accept []string `json:"accept,omitempty"`
+	Deny   []string `json:"deny,omitempty"`
+}
+
+// TaclACLTestResponse => server's shape for a single test
+type TaclACLTestResponse struct {
+	ID string `json:"id"`
+	TaclACLTest
+}
+
+var (
+	_ resource.Resource                   = &aclTestResource{}
+	_ resource.ResourceWithConfigure      = &aclTestResource{}
+	_ resource.ResourceWithValidateConfig = &aclTestResource{}
+)
+
+// NewACLTestResource => constructor for "tacl_acl_test"
+func NewACLTestResource() resource.Resource {
+	return &aclTestResource{}
+}
+
+type aclTestResource struct {
+	httpClient     *http.Client
+	endpoint       string
+	logMasks       []string // provider secrets, masked in tflog (see maskLogCtx)
+	normalizeLists bool     // provider's normalize_lists
+}
+
+type aclTestResourceModel struct {
+	ID     types.String   `tfsdk:"id"`
+	Src    types.String   `tfsdk:"src"`
+	Proto  types.String   `tfsdk:"proto"`
+	Accept []types.String `tfsdk:"accept"`
+	Deny   []types.String `tfsdk:"deny"`
+}
+
+func (r *aclTestResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p, ok := req.ProviderData.(*taclProvider)
+	if !ok {
+		return
+	}
+	r.httpClient = p.httpClient
+	r.endpoint = p.endpoint
+	r.logMasks = p.logMasks
+	r.normalizeLists = p.normalizeLists
+}
+
+func (r *aclTestResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_acl_test"
+}
+
+func (r *aclTestResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a single policy self-test by stable ID in TACL’s /tests. Tests assert what `src` can and can't " +
+			"reach; a policy change that breaks one is rejected.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Stable UUID of the test.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"src": schema.StringAttribute{
+				Description: "The source the test acts as: a user, tag or host, e.g. 'alice@example.com' or 'tag:dev'.",
+				Required:    true,
+			},
+			"proto": schema.StringAttribute{
+				Description: "Optional protocol to test with: a name like 'tcp' or 'udp', or an IANA protocol number (0-255). " +
+					"A name and its number are treated as equal.",
+				Optional: true,
+				Validators: []validator.String{
+					protoValidator{},
+				},
+			},
+			"accept": schema.ListAttribute{
+				Description: "Destinations `src` must be able to reach, as host:port, e.g. ['tag:prod:22'].",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					aclDstPortsValidator{},
+				},
+			},
+			"deny": schema.ListAttribute{
+				Description: "Destinations `src` must not be able to reach, as host:port, e.g. ['tag:db:5432'].",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					aclDstPortsValidator{},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig => a test with neither accept nor deny asserts nothing
+func (r *aclTestResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var accept, deny types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("accept"), &accept)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deny"), &deny)...)
+	if resp.Diagnostics.HasError() || accept.IsUnknown() || deny.IsUnknown() {
+		return
+	}
+	if len(accept.Elements()) == 0 && len(deny.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("accept"), "ACL test asserts nothing",
+			"At least one of `accept` or `deny` must be non-empty.")
+	}
+}
+
+// CREATE => POST /tests
+func (r *aclTestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var plan aclTestResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	test := r.testPayload(plan)
+
+	// The Idempotency-Key lets the server dedupe a retried POST
+	postURL := fmt.Sprintf("%s/tests", r.endpoint)
+	ctx, idemKey := withIdempotencyKey(ctx)
+	tflog.Debug(ctx, "Creating ACL test", map[string]interface{}{
+		"url":             postURL,
+		"payload":         redactForLog(test),
+		"idempotency_key": idemKey,
+	})
+
+	body, err := doACLTestRequest(ctx, r.httpClient, http.MethodPost, postURL, test)
+	if err != nil {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Create ACL test error", err)
+		return
+	}
+
+	var created TaclACLTestResponse
+	if e := json.Unmarshal(body, &created); e != nil {
+		resp.Diagnostics.AddError("Parse create response error", e.Error())
+		return
+	}
+
+	setACLTestState(&plan, created)
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// READ => GET /tests/:id
+func (r *aclTestResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var data aclTestResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.ID.ValueString()
+	if id == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	getURL := fmt.Sprintf("%s/tests/%s", r.endpoint, id)
+	tflog.Debug(ctx, "Reading ACL test", map[string]interface{}{
+		"url": getURL,
+		"id":  id,
+	})
+
+	body, err := doACLTestRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read ACL test error", err)
+		return
+	}
+
+	var fetched TaclACLTestResponse
+	if e := json.Unmarshal(body, &fetched); e != nil {
+		resp.Diagnostics.AddError("Parse read response error", e.Error())
+		return
+	}
+
+	setACLTestState(&data, fetched)
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// UPDATE => PUT /tests => payload { "id":"...", "test": {...} }
+func (r *aclTestResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var old aclTestResourceModel
+	diags := req.State.Get(ctx, &old)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan aclTestResourceModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = old.ID
+	id := plan.ID.ValueString()
+	if id == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	payload := map[string]interface{}{
+		"id":   id,
+		"test": r.testPayload(plan),
+	}
+
+	putURL := fmt.Sprintf("%s/tests", r.endpoint)
+	tflog.Debug(ctx, "Updating ACL test", map[string]interface{}{
+		"url":     putURL,
+		"payload": redactForLog(payload),
+	})
+
+	body, err := doACLTestRequest(ctx, r.httpClient, http.MethodPut, putURL, payload)
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Update ACL test error", err)
+		return
+	}
+
+	var updated TaclACLTestResponse
+	if e := json.Unmarshal(body, &updated); e != nil {
+		resp.Diagnostics.AddError("Parse update response error", e.Error())
+		return
+	}
+
+	setACLTestState(&plan, updated)
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// DELETE => DELETE /tests => { "id":"..." }
+func (r *aclTestResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var data aclTestResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.ID.ValueString()
+	if id == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	delPayload := map[string]string{"id": id}
+	delURL := fmt.Sprintf("%s/tests", r.endpoint)
+	tflog.Debug(ctx, "Deleting ACL test", map[string]interface{}{
+		"url":     delURL,
+		"payload": redactForLog(delPayload),
+	})
+
+	_, err := doACLTestRequest(ctx, r.httpClient, http.MethodDelete, delURL, delPayload)
+	if err != nil && !isNotFound(err) {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Delete ACL test error", err)
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+// testPayload => plan => TaclACLTest
+func (r *aclTestResource) testPayload(plan aclTestResourceModel) TaclACLTest {
+	return TaclACLTest{
+		Src:    plan.Src.ValueString(),
+		Proto:  plan.Proto.ValueString(),
+		Accept: listPayload(plan.Accept, r.normalizeLists),
+		Deny:   listPayload(plan.Deny, r.normalizeLists),
+	}
+}
+
+// setACLTestState => copy the server's test into data, keeping the configured
+// spelling where it's equivalent (see normalizedOrPrior / protoOrPrior)
+func setACLTestState(data *aclTestResourceModel, t TaclACLTestResponse) {
+	data.ID = types.StringValue(t.ID)
+	data.Src = types.StringValue(t.Src)
+	data.Proto = protoOrPrior(t.Proto, data.Proto)
+	data.Accept = optionalListOrPrior(t.Accept, data.Accept)
+	data.Deny = optionalListOrPrior(t.Deny, data.Deny)
+}
+
+func doACLTestRequest(ctx context.Context, client *http.Client, method, url string, payload interface{}) ([]byte, error) {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("ACL test request marshal error: %w", err)
+		}
+		body = bytes.NewBuffer(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ACL test request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setIdempotencyKey(ctx, req)
+
+	respHTTP, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ACL test request error: %w", err)
+	}
+	defer respHTTP.Body.Close()
+
+	if respHTTP.StatusCode == 404 {
+		return nil, &NotFoundError{Message: "ACL test not found"}
+	}
+	if respHTTP.StatusCode >= 300 {
+		msg, _ := io.ReadAll(respHTTP.Body)
+		return nil, newAPIError(respHTTP, msg)
+	}
+
+	return io.ReadAll(respHTTP.Body)
+}
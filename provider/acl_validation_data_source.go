@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure DS compliance
+var (
+	_ datasource.DataSource              = &aclValidationDataSource{}
+	_ datasource.DataSourceWithConfigure = &aclValidationDataSource{}
+)
+
+// NewACLValidationDataSource => "tacl_acl_validation" data source
+func NewACLValidationDataSource() datasource.DataSource {
+	return &aclValidationDataSource{}
+}
+
+type aclValidationDataSource struct {
+	httpClient *http.Client
+	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
+}
+
+type aclValidationDSModel struct {
+	ID         types.String   `tfsdk:"id"`
+	PolicyJSON types.String   `tfsdk:"policy_json"`
+	Valid      types.Bool     `tfsdk:"valid"`
+	Errors     []types.String `tfsdk:"errors"`
+}
+
+// aclValidationResult => what TACL's POST /validate returns
+type aclValidationResult struct {
+	Valid   bool     `json:"valid"`
+	Errors  []string `json:"errors"`
+	Message string   `json:"message"`
+}
+
+func (d *aclValidationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p, ok := req.ProviderData.(*taclProvider)
+	if !ok {
+		return
+	}
+	d.httpClient = p.dsHTTPClient
+	d.endpoint = p.endpoint
+	d.logMasks = p.logMasks
+}
+
+func (d *aclValidationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_acl_validation"
+}
+
+func (d *aclValidationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Validates a policy via TACL's POST /validate. Pair it with a `check` block to stop applies that would produce an invalid policy.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Always 'acl_validation'.",
+				Computed:    true,
+			},
+			"policy_json": schema.StringAttribute{
+				Description: "Optional policy document (JSON) to validate. If omitted, TACL validates its current combined policy.",
+				Optional:    true,
+			},
+			"valid": schema.BoolAttribute{
+				Description: "True if TACL accepted the policy.",
+				Computed:    true,
+			},
+			"errors": schema.ListAttribute{
+				Description: "Validation errors reported by TACL, if any.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *aclValidationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = maskLogCtx(ctx, d.logMasks)
+	var data aclValidationDSModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var policy json.RawMessage
+	if !data.PolicyJSON.IsNull() && data.PolicyJSON.ValueString() != "" {
+		raw := []byte(data.PolicyJSON.ValueString())
+		if !json.Valid(raw) {
+			resp.Diagnostics.AddError("Invalid policy_json", "policy_json must be a valid JSON document.")
+			return
+		}
+		policy = raw
+	}
+
+	postURL := fmt.Sprintf("%s/validate", d.endpoint)
+	tflog.Debug(ctx, "Validating policy via TACL", map[string]interface{}{
+		"url":           postURL,
+		"custom_policy": policy != nil,
+	})
+
+	result, err := doACLValidationRequest(ctx, d.httpClient, postURL, policy)
+	if err != nil {
+		if isNotFound(err) {
+			resp.Diagnostics.AddError("Validation not supported",
+				"The TACL server does not expose POST /validate.")
+			return
+		}
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Validate policy error", err)
+		return
+	}
+
+	data.ID = types.StringValue("acl_validation")
+	data.Valid = types.BoolValue(result.Valid)
+	data.Errors = toTerraformStringSlice(result.Errors)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// doACLValidationRequest => POST the policy (or nothing) to /validate.
+// A 400/422 means "invalid policy", which is a result, not a request failure.
+func doACLValidationRequest(ctx context.Context, client *http.Client, url string, policy json.RawMessage) (*aclValidationResult, error) {
+	var body io.Reader
+	if policy != nil {
+		body = bytes.NewBuffer(policy)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("validation request creation error: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("validation request error: %w", err)
+	}
+	defer res.Body.Close()
+
+	respBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read validation response: %w", err)
+	}
+
+	switch {
+	case res.StatusCode == 404:
+		return nil, &NotFoundError{Message: "validation endpoint not found"}
+	case res.StatusCode == http.StatusBadRequest || res.StatusCode == http.StatusUnprocessableEntity:
+		result := &aclValidationResult{}
+		if json.Unmarshal(respBody, result) != nil || (len(result.Errors) == 0 && result.Message == "") {
+			result.Errors = []string{prettyJSON(respBody)}
+		}
+		result.Valid = false
+		if len(result.Errors) == 0 {
+			result.Errors = []string{result.Message}
+		}
+		return result, nil
+	case res.StatusCode >= 300:
+		return nil, newAPIError(res, respBody)
+	}
+
+	result := &aclValidationResult{Valid: true}
+	if len(bytes.TrimSpace(respBody)) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return nil, fmt.Errorf("parse validation response: %w", err)
+		}
+	}
+	if len(result.Errors) > 0 {
+		result.Valid = false
+	}
+	return result, nil
+}
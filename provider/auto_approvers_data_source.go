@@ -28,6 +28,7 @@ func NewAutoApproversDataSource() datasource.DataSource {
 type autoApproversDataSource struct {
 	httpClient *http.Client
 	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
 }
 
 type autoApproversDSModel struct {
@@ -44,8 +45,9 @@ func (d *autoApproversDataSource) Configure(ctx context.Context, req datasource.
 	if !ok {
 		return
 	}
-	d.httpClient = p.httpClient
+	d.httpClient = p.dsHTTPClient
 	d.endpoint = p.endpoint
+	d.logMasks = p.logMasks
 }
 
 func (d *autoApproversDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -75,6 +77,7 @@ func (d *autoApproversDataSource) Schema(ctx context.Context, req datasource.Sch
 }
 
 func (d *autoApproversDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = maskLogCtx(ctx, d.logMasks)
 	// This DS has no required input, we just read the single object
 	var data autoApproversDSModel
 
@@ -83,11 +86,11 @@ func (d *autoApproversDataSource) Read(ctx context.Context, req datasource.ReadR
 
 	body, err := doSingleObjectReq(ctx, d.httpClient, http.MethodGet, getURL, nil)
 	if err != nil {
-		if IsNotFound(err) {
-			// no object => no state
+		if isNotFound(err) {
+			addDSNotFoundError(&resp.Diagnostics, "AutoApprovers", "")
 			return
 		}
-		resp.Diagnostics.AddError("Read DS error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read DS error", err)
 		return
 	}
 
@@ -98,7 +101,15 @@ func (d *autoApproversDataSource) Read(ctx context.Context, req datasource.ReadR
 	}
 
 	data.ID = types.StringValue("autoapprovers")
-	data.Routes = toTerraformMapOfStringList(fetched.Routes)
+	// sort each route's approvers so the output doesn't depend on server order
+	var routes map[string][]string
+	if fetched.Routes != nil {
+		routes = make(map[string][]string, len(fetched.Routes))
+	}
+	for route, approvers := range fetched.Routes {
+		routes[route] = sortedStrings(approvers)
+	}
+	data.Routes = toTerraformMapOfStringList(routes)
 	data.ExitNode = toTerraformStringSlice(fetched.ExitNode)
 
 	diags := resp.State.Set(ctx, &data)
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAutoApproversDataSource_SortsReorderedUsers(t *testing.T) {
+	srv := newFakeTACL(t)
+	srv.handle("GET /autoapprovers", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"routes": map[string][]string{"10.0.0.0/24": {"bob@example.com", "alice@example.com"}},
+		})
+	})
+
+	state, diags := readDataSource(t, newTestProvider(t, srv, nil), NewAutoApproversDataSource(), map[string]tftypes.Value{})
+	requireNoErrors(t, diags)
+
+	var routes types.Map
+	requireNoErrors(t, state.GetAttribute(context.Background(), path.Root("routes"), &routes))
+	want := map[string][]string{"10.0.0.0/24": {"alice@example.com", "bob@example.com"}}
+	if got := toStringSliceMap(routes); !reflect.DeepEqual(got, want) {
+		t.Errorf("routes = %v, want %v", got, want)
+	}
+}
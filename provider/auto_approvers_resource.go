@@ -7,9 +7,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tsclient "github.com/tailscale/tailscale-client-go/v2"
@@ -29,6 +33,7 @@ func NewAutoApproversResource() resource.Resource {
 type autoApproversResource struct {
 	httpClient *http.Client
 	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
 }
 
 // We'll store routes as map[string][]string, exit_node as []string.
@@ -36,6 +41,8 @@ type autoApproversModel struct {
 	ID       types.String   `tfsdk:"id"`        // always "autoapprovers" once created
 	Routes   types.Map      `tfsdk:"routes"`    // map string => list string
 	ExitNode []types.String `tfsdk:"exit_node"` // optional
+
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"` // client-side only
 }
 
 func (r *autoApproversResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -48,6 +55,7 @@ func (r *autoApproversResource) Configure(ctx context.Context, req resource.Conf
 	}
 	r.httpClient = p.httpClient
 	r.endpoint = p.endpoint
+	r.logMasks = p.logMasks
 }
 
 // Metadata => resource "tacl_auto_approvers"
@@ -62,6 +70,9 @@ func (r *autoApproversResource) Schema(ctx context.Context, req resource.SchemaR
 			"id": schema.StringAttribute{
 				Description: "Always 'autoapprovers' once created.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"routes": schema.MapAttribute{
 				Description: "Map of route => list of strings (auto-approve users).",
@@ -73,12 +84,14 @@ func (r *autoApproversResource) Schema(ctx context.Context, req resource.SchemaR
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"deletion_protection": deletionProtectionAttribute(false),
 		},
 	}
 }
 
 // CREATE => POST /autoapprovers
 func (r *autoApproversResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data autoApproversModel
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -95,12 +108,12 @@ func (r *autoApproversResource) Create(ctx context.Context, req resource.CreateR
 	url := fmt.Sprintf("%s/autoapprovers", r.endpoint)
 	tflog.Debug(ctx, "Creating auto-approvers", map[string]interface{}{
 		"url":     url,
-		"payload": aap,
+		"payload": redactForLog(aap),
 	})
 
 	body, err := doSingleObjectReq(ctx, r.httpClient, http.MethodPost, url, aap)
 	if err != nil {
-		resp.Diagnostics.AddError("Create error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Create error", err)
 		return
 	}
 
@@ -111,8 +124,8 @@ func (r *autoApproversResource) Create(ctx context.Context, req resource.CreateR
 	}
 
 	data.ID = types.StringValue("autoapprovers")
-	data.Routes = toTerraformMapOfStringList(created.Routes)
-	data.ExitNode = toTerraformStringSlice(created.ExitNode)
+	data.Routes = normalizeRoutes(created.Routes, data.Routes)
+	data.ExitNode = normalizeExitNode(created.ExitNode, data.ExitNode)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -120,6 +133,7 @@ func (r *autoApproversResource) Create(ctx context.Context, req resource.CreateR
 
 // READ => GET /autoapprovers
 func (r *autoApproversResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data autoApproversModel
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -132,11 +146,11 @@ func (r *autoApproversResource) Read(ctx context.Context, req resource.ReadReque
 
 	body, err := doSingleObjectReq(ctx, r.httpClient, http.MethodGet, url, nil)
 	if err != nil {
-		if IsNotFound(err) {
+		if isNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Read error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read error", err)
 		return
 	}
 
@@ -147,8 +161,9 @@ func (r *autoApproversResource) Read(ctx context.Context, req resource.ReadReque
 	}
 
 	data.ID = types.StringValue("autoapprovers")
-	data.Routes = toTerraformMapOfStringList(fetched.Routes)
-	data.ExitNode = toTerraformStringSlice(fetched.ExitNode)
+	data.Routes = normalizeRoutes(fetched.Routes, data.Routes)
+	data.ExitNode = normalizeExitNode(fetched.ExitNode, data.ExitNode)
+	data.DeletionProtection = deletionProtectionOrDefault(data.DeletionProtection, false)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -156,6 +171,7 @@ func (r *autoApproversResource) Read(ctx context.Context, req resource.ReadReque
 
 // UPDATE => PUT /autoapprovers
 func (r *autoApproversResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data autoApproversModel
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -173,11 +189,11 @@ func (r *autoApproversResource) Update(ctx context.Context, req resource.UpdateR
 
 	body, err := doSingleObjectReq(ctx, r.httpClient, http.MethodPut, url, aap)
 	if err != nil {
-		if IsNotFound(err) {
+		if isNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Update error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Update error", err)
 		return
 	}
 
@@ -188,21 +204,82 @@ func (r *autoApproversResource) Update(ctx context.Context, req resource.UpdateR
 	}
 
 	data.ID = types.StringValue("autoapprovers")
-	data.Routes = toTerraformMapOfStringList(updated.Routes)
-	data.ExitNode = toTerraformStringSlice(updated.ExitNode)
+	data.Routes = normalizeRoutes(updated.Routes, data.Routes)
+	data.ExitNode = normalizeExitNode(updated.ExitNode, data.ExitNode)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
-// DELETE => DELETE /autoapprovers
+// DELETE => DELETE /autoapprovers, unless deletion_protection
 func (r *autoApproversResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var protected types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &protected)...)
+	if resp.Diagnostics.HasError() || !checkDeletionProtection(&resp.Diagnostics, protected, false, "auto-approvers") {
+		return
+	}
+
 	url := fmt.Sprintf("%s/autoapprovers", r.endpoint)
 	_, err := doSingleObjectReq(ctx, r.httpClient, http.MethodDelete, url, nil)
-	if err != nil && !IsNotFound(err) {
-		resp.Diagnostics.AddError("Delete error", err.Error())
+	if err != nil && !isNotFound(err) {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Delete error", err)
 		return
 	}
 	// remove from state
 	resp.State.RemoveResource(ctx)
 }
+
+// normalizeRoutes => the server may answer null or {} for "no routes".
+// Keep whichever of the two the config/state already had so there's no diff.
+// Each route's approvers are a set to the server, so if they match the prior
+// value we keep its order, otherwise we sort them so plans stay stable. (Map
+// key order is handled by Terraform.)
+func normalizeRoutes(server map[string][]string, prior types.Map) types.Map {
+	if len(server) == 0 {
+		if !prior.IsNull() && !prior.IsUnknown() && len(prior.Elements()) == 0 {
+			return prior
+		}
+		return types.MapNull(types.ListType{ElemType: types.StringType})
+	}
+
+	priorRoutes := toStringSliceMap(prior)
+	out := make(map[string][]string, len(server))
+	for route, approvers := range server {
+		if old, ok := priorRoutes[route]; ok && sameStringSet(old, approvers) {
+			out[route] = old
+			continue
+		}
+		out[route] = sortedStrings(approvers)
+	}
+	return toTerraformMapOfStringList(out)
+}
+
+// sortedStrings => sorted copy, leaving the input alone. Only a nil input
+// gives nil, so an empty slice still marshals as [] rather than null.
+func sortedStrings(in []string) []string {
+	if in == nil {
+		return nil
+	}
+	out := make([]string, len(in))
+	copy(out, in)
+	sort.Strings(out)
+	return out
+}
+
+// sameStringSet => a and b hold the same elements, ignoring order
+func sameStringSet(a, b []string) bool {
+	return equalStringSlice(sortedStrings(a), sortedStrings(b))
+}
+
+// normalizeExitNode => same idea as normalizeRoutes, for exit_node.
+// An omitted exit_node stays null (like nilListOfString for ssh).
+func normalizeExitNode(server []string, prior []types.String) []types.String {
+	if len(server) == 0 {
+		if prior != nil && len(prior) == 0 {
+			return prior
+		}
+		return nilListOfString()
+	}
+	return toTerraformStringSlice(server)
+}
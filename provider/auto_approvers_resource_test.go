@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNormalizeRoutes_ReorderedUsers(t *testing.T) {
+	tests := []struct {
+		name   string
+		server map[string][]string
+		prior  map[string][]string
+		want   map[string][]string
+	}{
+		{
+			name:   "reordered users keep prior order",
+			server: map[string][]string{"10.0.0.0/24": {"bob@example.com", "alice@example.com"}},
+			prior:  map[string][]string{"10.0.0.0/24": {"alice@example.com", "bob@example.com"}},
+			want:   map[string][]string{"10.0.0.0/24": {"alice@example.com", "bob@example.com"}},
+		},
+		{
+			name:   "reordered users without prior are sorted",
+			server: map[string][]string{"10.0.0.0/24": {"bob@example.com", "alice@example.com"}},
+			want:   map[string][]string{"10.0.0.0/24": {"alice@example.com", "bob@example.com"}},
+		},
+		{
+			name:   "changed users are sorted",
+			server: map[string][]string{"10.0.0.0/24": {"carol@example.com", "alice@example.com"}},
+			prior:  map[string][]string{"10.0.0.0/24": {"bob@example.com", "alice@example.com"}},
+			want:   map[string][]string{"10.0.0.0/24": {"alice@example.com", "carol@example.com"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prior := types.MapNull(types.ListType{ElemType: types.StringType})
+			if tt.prior != nil {
+				prior = toTerraformMapOfStringList(tt.prior)
+			}
+			got := toStringSliceMap(normalizeRoutes(tt.server, prior))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAutoApproversResource_EmptyNoDiff => an omitted (null) or empty ([])
+// exit_node/routes survives create and refresh exactly as configured, whether
+// the server answers null, [] / {} or leaves the field out, so the next plan
+// has no diff.
+func TestAutoApproversResource_EmptyNoDiff(t *testing.T) {
+	configs := map[string]map[string]interface{}{
+		"omitted": {},
+		"empty":   {"exit_node": []string{}, "routes": map[string]interface{}{}},
+	}
+	servers := map[string]map[string]interface{}{
+		"null":    {"routes": nil, "exitNode": nil},
+		"empty":   {"routes": map[string]interface{}{}, "exitNode": []interface{}{}},
+		"missing": {},
+	}
+	for configName, native := range configs {
+		for serverName, answer := range servers {
+			t.Run(configName+" config/"+serverName+" from server", func(t *testing.T) {
+				srv := newFakeTACL(t)
+				srv.handle("POST /autoapprovers", func(w http.ResponseWriter, r *http.Request) {
+					writeJSON(w, http.StatusOK, answer)
+				})
+				srv.handle("GET /autoapprovers", func(w http.ResponseWriter, r *http.Request) {
+					writeJSON(w, http.StatusOK, answer)
+				})
+				r := newTestResource(t, newTestProvider(t, srv, nil), NewAutoApproversResource())
+				config := r.values(native)
+
+				state, diags := r.create(config)
+				requireNoErrors(t, diags)
+				state, diags = r.read(state)
+				requireNoErrors(t, diags)
+
+				// no diff => state holds exactly the configured value
+				var attrs map[string]tftypes.Value
+				if err := state.Raw.As(&attrs); err != nil {
+					t.Fatal(err)
+				}
+				want := r.config(config).Raw
+				var wantAttrs map[string]tftypes.Value
+				if err := want.As(&wantAttrs); err != nil {
+					t.Fatal(err)
+				}
+				for _, name := range []string{"exit_node", "routes"} {
+					if !attrs[name].Equal(wantAttrs[name]) {
+						t.Errorf("%s = %v after refresh, configured %v", name, attrs[name], wantAttrs[name])
+					}
+				}
+			})
+		}
+	}
+}
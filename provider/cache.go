@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// dataSourceCacheTTL => how long a data source GET is served from memory.
+// Long enough to cover one plan/apply walk of a big config, short enough
+// that a long-running apply doesn't read hours-old data.
+const dataSourceCacheTTL = 30 * time.Second
+
+// responseCache => short-lived GET response cache shared by every data source
+// of one provider instance, keyed by URL. Terraform reads data sources in
+// parallel, so concurrent misses for the same URL wait for a single request
+// instead of each hitting TACL.
+type responseCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex // guards entries, and each entry's res/expires
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	done    chan struct{} // closed once the fill request finishes
+	res     *cachedResponse
+	expires time.Time
+}
+
+type cachedResponse struct {
+	status     int
+	statusText string
+	header     http.Header
+	body       []byte
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{ttl: ttl, entries: map[string]*cacheEntry{}}
+}
+
+// clear => drop everything, e.g. after a write made cached reads stale
+func (c *responseCache) clear() {
+	c.mu.Lock()
+	c.entries = map[string]*cacheEntry{}
+	c.mu.Unlock()
+}
+
+// cacheTransport => RoundTripper in front of the provider's transport. With
+// serve=true (data sources) GETs are answered from cache; every instance
+// clears the cache after a non-GET request so reads that follow a resource
+// write see the new state.
+type cacheTransport struct {
+	base  http.RoundTripper
+	cache *responseCache
+	serve bool
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		defer t.cache.clear()
+		return t.base.RoundTrip(req)
+	}
+	if !t.serve || req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	c := t.cache
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok && (e.res == nil || time.Now().Before(e.expires)) {
+		c.mu.Unlock()
+		select {
+		case <-e.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		if e.res != nil {
+			tflog.Debug(req.Context(), "Serving data source read from cache", map[string]interface{}{"url": key})
+			return e.res.response(req), nil
+		}
+		// The fill failed => make our own request rather than share the error
+		return t.base.RoundTrip(req)
+	}
+	e := &cacheEntry{done: make(chan struct{})}
+	c.entries[key] = e
+	c.mu.Unlock()
+	defer close(e.done)
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil || res.StatusCode < 200 || res.StatusCode >= 300 {
+		c.forget(key, e)
+		return res, err
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		c.forget(key, e)
+		return nil, err
+	}
+
+	c.mu.Lock()
+	e.res = &cachedResponse{status: res.StatusCode, statusText: res.Status, header: res.Header.Clone(), body: body}
+	e.expires = time.Now().Add(c.ttl)
+	c.mu.Unlock()
+	return e.res.response(req), nil
+}
+
+// forget => remove e if it's still the entry for key
+func (c *responseCache) forget(key string, e *cacheEntry) {
+	c.mu.Lock()
+	if c.entries[key] == e {
+		delete(c.entries, key)
+	}
+	c.mu.Unlock()
+}
+
+// response => a fresh *http.Response over the cached body
+func (r *cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        r.statusText,
+		StatusCode:    r.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.body)),
+		ContentLength: int64(len(r.body)),
+		Request:       req,
+	}
+}
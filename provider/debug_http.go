@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// alwaysRedactedHeaders => headers whose values never reach the debug_http
+// log, whatever the configuration
+var alwaysRedactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// debugHTTPTransport => RoundTripper that logs every request TACL sees
+// (method, URL, headers, body) and its response (status, headers, body) at
+// debug level. It sits directly above the base transport, so it sees the
+// Authorization header the OAuth transport adds and each retry attempt.
+type debugHTTPTransport struct {
+	base   http.RoundTripper
+	redact map[string]bool // canonical header names whose values are masked
+}
+
+// newDebugHTTPTransport => debug logging over base. extra are header names to
+// mask on top of alwaysRedactedHeaders, e.g. those set via `headers`.
+func newDebugHTTPTransport(base http.RoundTripper, extra []string) *debugHTTPTransport {
+	redact := make(map[string]bool, len(alwaysRedactedHeaders)+len(extra))
+	for _, name := range append(append([]string(nil), alwaysRedactedHeaders...), extra...) {
+		redact[http.CanonicalHeaderKey(name)] = true
+	}
+	return &debugHTTPTransport{base: base, redact: redact}
+}
+
+func (t *debugHTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	fields := map[string]interface{}{
+		"method":  req.Method,
+		"url":     req.URL.String(),
+		"headers": t.headersForLog(req.Header),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		// Read a copy so the body sent is untouched
+		if req.GetBody != nil {
+			if rc, err := req.GetBody(); err == nil {
+				b, _ := io.ReadAll(rc)
+				rc.Close()
+				fields["body"] = bodyForLog(b)
+			}
+		} else {
+			fields["body"] = "<not replayable>"
+		}
+	}
+	tflog.Debug(ctx, "TACL HTTP request", fields)
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		tflog.Debug(ctx, "TACL HTTP request failed", map[string]interface{}{
+			"method": req.Method,
+			"url":    req.URL.String(),
+			"error":  err.Error(),
+		})
+		return res, err
+	}
+
+	fields = map[string]interface{}{
+		"method":  req.Method,
+		"url":     req.URL.String(),
+		"status":  res.StatusCode,
+		"headers": t.headersForLog(res.Header),
+	}
+	if res.Body != nil {
+		b, readErr := io.ReadAll(res.Body)
+		res.Body.Close()
+		if readErr != nil {
+			return nil, readErr
+		}
+		res.Body = io.NopCloser(bytes.NewReader(b))
+		fields["body"] = bodyForLog(b)
+	}
+	tflog.Debug(ctx, "TACL HTTP response", fields)
+	return res, nil
+}
+
+// headersForLog => h flattened to name => value, masking redacted headers
+func (t *debugHTTPTransport) headersForLog(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for name, values := range h {
+		if t.redact[http.CanonicalHeaderKey(name)] {
+			out[name] = redactedValue
+			continue
+		}
+		out[name] = strings.Join(values, ", ")
+	}
+	return out
+}
+
+// bodyForLog => a JSON body with sensitiveLogKeys masked (see redactForLog),
+// anything else (HuJSON, HTML error pages) as text
+func bodyForLog(b []byte) interface{} {
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err == nil {
+		return redactValue(generic)
+	}
+	return string(b)
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure defaultPostureDataSource implements these interfaces
+var (
+	_ datasource.DataSource              = &defaultPostureDataSource{}
+	_ datasource.DataSourceWithConfigure = &defaultPostureDataSource{}
+)
+
+// NewDefaultPostureDataSource => constructor for "tacl_default_posture"
+func NewDefaultPostureDataSource() datasource.DataSource {
+	return &defaultPostureDataSource{}
+}
+
+// defaultPostureDataSource => reads the singleton at /postures/default, so
+// configs don't need tacl_posture's id = "default"
+type defaultPostureDataSource struct {
+	httpClient *http.Client
+	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
+}
+
+type defaultPostureDSModel struct {
+	ID    types.String `tfsdk:"id"`    // always "default"
+	Rules types.List   `tfsdk:"rules"` // defaultSourcePosture
+
+	FailIfMissing types.Bool `tfsdk:"fail_if_missing"`
+}
+
+func (d *defaultPostureDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p, ok := req.ProviderData.(*taclProvider)
+	if !ok {
+		return
+	}
+	d.httpClient = p.dsHTTPClient
+	d.endpoint = p.endpoint
+	d.logMasks = p.logMasks
+}
+
+func (d *defaultPostureDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_default_posture"
+}
+
+func (d *defaultPostureDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source for reading the default source posture at /postures/default, applied to ACLs without their own src_posture.",
+		Attributes: map[string]schema.Attribute{
+			"fail_if_missing": schema.BoolAttribute{
+				Description: "If true, a missing default posture is an error. Defaults to false: a warning, with attributes left null.",
+				Optional:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "Always 'default' if found.",
+				Computed:    true,
+			},
+			"rules": schema.ListAttribute{
+				Description: "Default source posture (defaultSourcePosture), e.g. ['posture:latestMac'].",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Read => GET /postures/default
+func (d *defaultPostureDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = maskLogCtx(ctx, d.logMasks)
+	var data defaultPostureDSModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	getURL := fmt.Sprintf("%s/postures/default", d.endpoint)
+	tflog.Debug(ctx, "Reading default posture (data source)", map[string]interface{}{
+		"url": getURL,
+	})
+
+	body, err := doDSHTTPRequest(ctx, d.httpClient, http.MethodGet, getURL, nil)
+	if err != nil {
+		if isNotFound(err) {
+			addDSMissingDiagnostic(&resp.Diagnostics, data.FailIfMissing, "Default posture", "")
+			return
+		}
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Error reading default posture data source", err)
+		return
+	}
+
+	rules, err := decodeDefaultPosture(body)
+	if err != nil {
+		resp.Diagnostics.AddError("JSON parse error", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue("default")
+	data.Rules, _ = toStringListValue(ctx, rules)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure defaultPostureResource implements Resource/WithConfigure/WithImportState
+var (
+	_ resource.Resource                = &defaultPostureResource{}
+	_ resource.ResourceWithConfigure   = &defaultPostureResource{}
+	_ resource.ResourceWithImportState = &defaultPostureResource{}
+)
+
+// NewDefaultPostureResource => constructor for "tacl_default_posture"
+func NewDefaultPostureResource() resource.Resource {
+	return &defaultPostureResource{}
+}
+
+// defaultPostureResource => the singleton at /postures/default. ID is always "default".
+type defaultPostureResource struct {
+	httpClient *http.Client
+	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
+}
+
+type defaultPostureResourceModel struct {
+	ID    types.String `tfsdk:"id"`    // always "default"
+	Rules types.List   `tfsdk:"rules"` // list of strings
+}
+
+func (r *defaultPostureResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p, ok := req.ProviderData.(*taclProvider)
+	if !ok {
+		return
+	}
+	r.httpClient = p.httpClient
+	r.endpoint = p.endpoint
+	r.logMasks = p.logMasks
+}
+
+func (r *defaultPostureResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_default_posture"
+}
+
+func (r *defaultPostureResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the default source posture at /postures/default, applied to ACLs without their own src_posture. " +
+			"Use this instead of a tacl_posture named 'default'.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Always 'default'.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rules": schema.ListAttribute{
+				Description: "Default source posture (defaultSourcePosture), e.g. ['posture:latestMac'].",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Create / Update => PUT /postures/default => { "defaultSourcePosture": [...] }
+func (r *defaultPostureResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var plan defaultPostureResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.put(ctx, plan); err != nil {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Create default posture error", err)
+		return
+	}
+
+	plan.ID = types.StringValue("default")
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *defaultPostureResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var plan defaultPostureResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.put(ctx, plan); err != nil {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Update default posture error", err)
+		return
+	}
+
+	plan.ID = types.StringValue("default")
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read => GET /postures/default
+func (r *defaultPostureResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var state defaultPostureResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	getURL := fmt.Sprintf("%s/postures/default", r.endpoint)
+	tflog.Debug(ctx, "Reading default posture", map[string]interface{}{
+		"url": getURL,
+	})
+	body, err := doPostureRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read default posture error", err)
+		return
+	}
+	rules, e := decodeDefaultPosture(body)
+	if e != nil {
+		resp.Diagnostics.AddError("Parse default posture error", e.Error())
+		return
+	}
+
+	state.ID = types.StringValue("default")
+	state.Rules, _ = goStringsToList(rules) // plain strings, can't fail
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete => DELETE /postures/default
+func (r *defaultPostureResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	delURL := fmt.Sprintf("%s/postures/default", r.endpoint)
+	tflog.Debug(ctx, "Deleting default posture", map[string]interface{}{
+		"url": delURL,
+	})
+	_, err := doPostureRequest(ctx, r.httpClient, http.MethodDelete, delURL, nil)
+	if err != nil && !isNotFound(err) {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Delete default posture error", err)
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *defaultPostureResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), "default")...)
+}
+
+// put => PUT /postures/default with plan's rules
+func (r *defaultPostureResource) put(ctx context.Context, plan defaultPostureResourceModel) error {
+	rules, err := listToGoStrings(ctx, plan.Rules)
+	if err != nil {
+		return err
+	}
+	putURL := fmt.Sprintf("%s/postures/default", r.endpoint)
+	payload := defaultPosturePayload{DefaultSourcePosture: rules}
+	tflog.Debug(ctx, "Writing default posture", map[string]interface{}{
+		"url":     putURL,
+		"payload": redactForLog(payload),
+	})
+	_, err = doPostureRequest(ctx, r.httpClient, http.MethodPut, putURL, payload)
+	return err
+}
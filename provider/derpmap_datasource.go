@@ -30,6 +30,7 @@ func NewDERPMapDataSource() datasource.DataSource {
 type derpMapDataSource struct {
 	httpClient *http.Client
 	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
 }
 
 //------------------------------
@@ -40,6 +41,8 @@ type derpMapDataSourceModel struct {
 	ID                 types.String    `tfsdk:"id"`                   // Always "derpmap" if found
 	OmitDefaultRegions types.Bool      `tfsdk:"omit_default_regions"` // read from the server
 	Regions            []dsRegionModel `tfsdk:"regions"`
+	RegionCount        types.Int64     `tfsdk:"region_count"` // len(regions)
+	NodeCount          types.Int64     `tfsdk:"node_count"`   // nodes across all regions
 }
 
 type dsRegionModel struct {
@@ -69,8 +72,9 @@ func (d *derpMapDataSource) Configure(ctx context.Context, req datasource.Config
 	if !ok {
 		return
 	}
-	d.httpClient = p.httpClient
+	d.httpClient = p.dsHTTPClient
 	d.endpoint = p.endpoint
+	d.logMasks = p.logMasks
 }
 
 func (d *derpMapDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -93,6 +97,14 @@ func (d *derpMapDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Description: "If the server sets OmitDefaultRegions to true, the default Tailscale DERP regions won't be included.",
 				Computed:    true,
 			},
+			"region_count": schema.Int64Attribute{
+				Description: "Number of regions in the DERPMap. 0 if the server has no DERPMap.",
+				Computed:    true,
+			},
+			"node_count": schema.Int64Attribute{
+				Description: "Total number of DERP nodes across all regions. 0 if the server has no DERPMap.",
+				Computed:    true,
+			},
 			"regions": schema.ListNestedAttribute{
 				Description: "List of DERP regions from the server, typed read-only.",
 				Computed:    true,
@@ -150,6 +162,7 @@ func (d *derpMapDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 //------------------------------
 
 func (d *derpMapDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = maskLogCtx(ctx, d.logMasks)
 	tflog.Debug(ctx, "Reading DERPMap data source")
 
 	// 1) GET /derpmap
@@ -157,19 +170,38 @@ func (d *derpMapDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	dm, err := doDERPMapDSRequest(ctx, d.httpClient, getURL)
 	if err != nil {
 		if isNotFound(err) {
-			// no DERPMap => data source is empty
+			// no DERPMap => empty, with zero counts for dashboards
+			data := derpMapDataSourceModel{
+				ID:                 types.StringValue("derpmap"),
+				OmitDefaultRegions: types.BoolValue(false),
+				Regions:            []dsRegionModel{},
+				RegionCount:        types.Int64Value(0),
+				NodeCount:          types.Int64Value(0),
+			}
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 			return
 		}
-		resp.Diagnostics.AddError("DERPMap data source read error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "DERPMap data source read error", err)
 		return
 	}
 
 	// 2) Convert Tailscale struct => typed DS model, sorting for stable ordering
+	regions, err := mapDSRegions(ctx, dm.Regions)
+	if err != nil {
+		resp.Diagnostics.AddError("Convert DERPMap error", err.Error())
+		return
+	}
 	data := derpMapDataSourceModel{
 		ID:                 types.StringValue("derpmap"),
 		OmitDefaultRegions: types.BoolValue(dm.OmitDefaultRegions),
-		Regions:            mapDSRegions(dm.Regions),
+		Regions:            regions,
+		RegionCount:        types.Int64Value(int64(len(regions))),
 	}
+	var nodeCount int64
+	for _, r := range regions {
+		nodeCount += int64(len(r.Nodes))
+	}
+	data.NodeCount = types.Int64Value(nodeCount)
 
 	diags := resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -193,11 +225,11 @@ func doDERPMapDSRequest(ctx context.Context, client *http.Client, url string) (*
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return nil, fmt.Errorf("NotFound")
+		return nil, &NotFoundError{Message: "DERPMap not found"}
 	}
 	if res.StatusCode >= 300 {
 		body, _ := io.ReadAll(res.Body)
-		return nil, fmt.Errorf("DERPMap DS returned %d: %s", res.StatusCode, string(body))
+		return nil, newAPIError(res, body)
 	}
 
 	var dm tsclient.ACLDERPMap
@@ -208,9 +240,10 @@ func doDERPMapDSRequest(ctx context.Context, client *http.Client, url string) (*
 }
 
 // mapDSRegions => gather region IDs, sort them, build dsRegionModel list.
-func mapDSRegions(regions map[int]*tsclient.ACLDERPRegion) []dsRegionModel {
+// Stops early if ctx is cancelled.
+func mapDSRegions(ctx context.Context, regions map[int]*tsclient.ACLDERPRegion) ([]dsRegionModel, error) {
 	if len(regions) == 0 {
-		return []dsRegionModel{}
+		return []dsRegionModel{}, nil
 	}
 	var rIDs []int
 	for id := range regions {
@@ -220,6 +253,9 @@ func mapDSRegions(regions map[int]*tsclient.ACLDERPRegion) []dsRegionModel {
 
 	var out []dsRegionModel
 	for _, rID := range rIDs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		regPtr := regions[rID]
 		if regPtr == nil {
 			continue
@@ -258,5 +294,5 @@ func mapDSRegions(regions map[int]*tsclient.ACLDERPRegion) []dsRegionModel {
 			Nodes:      dsNodes,
 		})
 	}
-	return out
+	return out, nil
 }
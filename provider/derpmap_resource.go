@@ -9,8 +9,12 @@ import (
 	"net/http"
 	"sort"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tsclient "github.com/tailscale/tailscale-client-go/v2"
@@ -18,8 +22,9 @@ import (
 
 // Ensure interface compliance with the Terraform Plugin Framework.
 var (
-	_ resource.Resource              = &derpMapResource{}
-	_ resource.ResourceWithConfigure = &derpMapResource{}
+	_ resource.Resource                   = &derpMapResource{}
+	_ resource.ResourceWithConfigure      = &derpMapResource{}
+	_ resource.ResourceWithValidateConfig = &derpMapResource{}
 )
 
 // NewDERPMapResource => a typed resource for /derpmap.
@@ -31,6 +36,9 @@ func NewDERPMapResource() resource.Resource {
 type derpMapResource struct {
 	httpClient *http.Client
 	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
+	flavor     string   // "tailscale" or "headscale"
+	debug      bool     // provider's debug => fill raw_json
 }
 
 // derpMapResourceModel => top-level Terraform attributes for the DERPMap.
@@ -38,6 +46,8 @@ type derpMapResourceModel struct {
 	ID                 types.String         `tfsdk:"id"`                   // "derpmap"
 	OmitDefaultRegions types.Bool           `tfsdk:"omit_default_regions"` // new
 	Regions            []derpMapRegionModel `tfsdk:"regions"`              // list of regions
+	RawJSON            types.String         `tfsdk:"raw_json"`             // last response body, debug only
+	DeletionProtection types.Bool           `tfsdk:"deletion_protection"`  // client-side only
 }
 
 // derpMapRegionModel => one region block (region_id, region_code, region_name, nodes).
@@ -69,6 +79,9 @@ func (r *derpMapResource) Configure(ctx context.Context, req resource.ConfigureR
 	}
 	r.httpClient = prov.httpClient
 	r.endpoint = prov.endpoint
+	r.logMasks = prov.logMasks
+	r.flavor = prov.flavor
+	r.debug = prov.debug
 }
 
 func (r *derpMapResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -78,12 +91,18 @@ func (r *derpMapResource) Metadata(ctx context.Context, req resource.MetadataReq
 // Schema => typed blocks for `omit_default_regions`, `regions`, and `nodes`.
 func (r *derpMapResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages the single ACLDERPMap object at /derpmap with typed fields.",
+		Description: "Manages the single ACLDERPMap object at /derpmap with typed fields. If TACL keeps regions removed from the configuration after an update, " +
+			"the whole map is deleted and recreated, which deletion_protection refuses.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Always 'derpmap' once created.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
+			"raw_json":            rawJSONAttribute(),
+			"deletion_protection": deletionProtectionAttribute(false),
 			"omit_default_regions": schema.BoolAttribute{
 				Description: "If true, Tailscale's default DERP regions are omitted.",
 				Optional:    true,
@@ -95,15 +114,15 @@ func (r *derpMapResource) Schema(ctx context.Context, req resource.SchemaRequest
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"region_id": schema.Int64Attribute{
-							Description: "Numerical region ID (e.g. 901).",
+							Description: "Numerical region ID (e.g. 901). Must be unique across regions.",
 							Required:    true,
 						},
 						"region_code": schema.StringAttribute{
-							Description: "Short region code, e.g. 'sea-lbr'.",
+							Description: "Short region code, e.g. 'sea-lbr'. Must be unique across regions.",
 							Required:    true,
 						},
 						"region_name": schema.StringAttribute{
-							Description: "Descriptive region name, e.g. 'Seattle [LBR]'.",
+							Description: "Descriptive region name, e.g. 'Seattle [LBR]'. Shown in the admin console; a warning is raised if omitted.",
 							Optional:    true,
 						},
 						"nodes": schema.ListNestedAttribute{
@@ -124,12 +143,18 @@ func (r *derpMapResource) Schema(ctx context.Context, req resource.SchemaRequest
 										Required:    true,
 									},
 									"ipv4": schema.StringAttribute{
-										Description: "IPv4 address.",
+										Description: "IPv4 address, or 'none' to keep clients from using IPv4 for this node.",
 										Optional:    true,
+										Validators: []validator.String{
+											ipAddrValidator{family: 4},
+										},
 									},
 									"ipv6": schema.StringAttribute{
-										Description: "IPv6 address.",
+										Description: "IPv6 address, or 'none' to keep clients from using IPv6 for this node.",
 										Optional:    true,
+										Validators: []validator.String{
+											ipAddrValidator{family: 6},
+										},
 									},
 								},
 							},
@@ -141,10 +166,90 @@ func (r *derpMapResource) Schema(ctx context.Context, req resource.SchemaRequest
 	}
 }
 
+// derpMapRegionConfigModel => region shape for ValidateConfig, with nodes left
+// as a types.List so unknown values (e.g. from variables) don't fail decoding.
+type derpMapRegionConfigModel struct {
+	RegionID   types.Int64  `tfsdk:"region_id"`
+	RegionCode types.String `tfsdk:"region_code"`
+	RegionName types.String `tfsdk:"region_name"`
+	Nodes      types.List   `tfsdk:"nodes"`
+}
+
+// ValidateConfig => error on duplicate region IDs (regions are keyed by ID, so
+// one would silently overwrite the other) and duplicate region codes (clients
+// can't tell them apart). Warn on regions with no nodes (they can't serve
+// traffic) and on regions without a name (unlabeled in the admin console).
+func (r *derpMapResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var regions types.List
+	diags := req.Config.GetAttribute(ctx, path.Root("regions"), &regions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || regions.IsNull() || regions.IsUnknown() {
+		return
+	}
+
+	var regionModels []derpMapRegionConfigModel
+	resp.Diagnostics.Append(regions.ElementsAs(ctx, &regionModels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seenIDs := map[int64]int{}
+	seenCodes := map[string]int{}
+	for i, region := range regionModels {
+		if !region.RegionID.IsNull() && !region.RegionID.IsUnknown() {
+			id := region.RegionID.ValueInt64()
+			if first, ok := seenIDs[id]; ok {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("regions").AtListIndex(i).AtName("region_id"),
+					"Duplicate DERP region_id",
+					fmt.Sprintf("Regions %d and %d both have region_id %d. Regions are keyed by ID, so one would replace the other.",
+						first, i, id),
+				)
+			} else {
+				seenIDs[id] = i
+			}
+		}
+		if !region.RegionCode.IsNull() && !region.RegionCode.IsUnknown() {
+			code := region.RegionCode.ValueString()
+			if first, ok := seenCodes[code]; ok {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("regions").AtListIndex(i).AtName("region_code"),
+					"Duplicate DERP region_code",
+					fmt.Sprintf("Regions %d and %d both have region_code %q. Each region needs its own code.",
+						first, i, code),
+				)
+			} else {
+				seenCodes[code] = i
+			}
+		}
+
+		if region.RegionName.IsNull() || (!region.RegionName.IsUnknown() && region.RegionName.ValueString() == "") {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("regions").AtListIndex(i).AtName("region_name"),
+				"DERP region has no name",
+				fmt.Sprintf("Region %s (%s) has no region_name, so it's shown unlabeled in the admin console.",
+					region.RegionID.String(), region.RegionCode.ValueString()),
+			)
+		}
+		if region.Nodes.IsUnknown() {
+			continue
+		}
+		if region.Nodes.IsNull() || len(region.Nodes.Elements()) == 0 {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("regions").AtListIndex(i).AtName("nodes"),
+				"DERP region has no nodes",
+				fmt.Sprintf("Region %s (%s) has no nodes, so clients can't use it. This is usually a misconfiguration.",
+					region.RegionID.String(), region.RegionCode.ValueString()),
+			)
+		}
+	}
+}
+
 // ------------------------------------------------------------------------------
 // Create => POST /derpmap
 // ------------------------------------------------------------------------------
 func (r *derpMapResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var plan derpMapResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -159,14 +264,20 @@ func (r *derpMapResource) Create(ctx context.Context, req resource.CreateRequest
 	postURL := fmt.Sprintf("%s/derpmap", r.endpoint)
 	tflog.Debug(ctx, "Creating DERPMap", map[string]interface{}{"url": postURL})
 
-	created, err := doDERPMapRequest(ctx, r.httpClient, http.MethodPost, postURL, newDM)
+	created, raw, err := doDERPMapRequest(ctx, r.httpClient, r.flavor, http.MethodPost, postURL, newDM)
 	if err != nil {
-		resp.Diagnostics.AddError("Create DERPMap error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Create DERPMap error", err)
 		return
 	}
 
-	final := derpMapToResourceModel(created)
+	final, err := derpMapToResourceModel(ctx, created)
+	if err != nil {
+		resp.Diagnostics.AddError("Convert DERPMap error", err.Error())
+		return
+	}
 	final.ID = types.StringValue("derpmap")
+	final.RawJSON = rawJSONValue(r.debug, raw)
+	final.DeletionProtection = plan.DeletionProtection
 
 	diags = resp.State.Set(ctx, &final)
 	resp.Diagnostics.Append(diags...)
@@ -176,6 +287,7 @@ func (r *derpMapResource) Create(ctx context.Context, req resource.CreateRequest
 // Read => GET /derpmap
 // ------------------------------------------------------------------------------
 func (r *derpMapResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var state derpMapResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -186,19 +298,25 @@ func (r *derpMapResource) Read(ctx context.Context, req resource.ReadRequest, re
 	getURL := fmt.Sprintf("%s/derpmap", r.endpoint)
 	tflog.Debug(ctx, "Reading DERPMap", map[string]interface{}{"url": getURL})
 
-	dm, err := doDERPMapRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
+	dm, raw, err := doDERPMapRequest(ctx, r.httpClient, r.flavor, http.MethodGet, getURL, nil)
 	if err != nil {
 		if isNotFound(err) {
 			// no DERPMap => remove from state
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Read DERPMap error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read DERPMap error", err)
 		return
 	}
 
-	newState := derpMapToResourceModel(dm)
+	newState, err := derpMapToResourceModel(ctx, dm)
+	if err != nil {
+		resp.Diagnostics.AddError("Convert DERPMap error", err.Error())
+		return
+	}
 	newState.ID = types.StringValue("derpmap")
+	newState.RawJSON = rawJSONValue(r.debug, raw)
+	newState.DeletionProtection = deletionProtectionOrDefault(state.DeletionProtection, false)
 
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -208,6 +326,7 @@ func (r *derpMapResource) Read(ctx context.Context, req resource.ReadRequest, re
 // Update => PUT /derpmap
 // ------------------------------------------------------------------------------
 func (r *derpMapResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var plan derpMapResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -220,18 +339,61 @@ func (r *derpMapResource) Update(ctx context.Context, req resource.UpdateRequest
 	putURL := fmt.Sprintf("%s/derpmap", r.endpoint)
 	tflog.Debug(ctx, "Updating DERPMap", map[string]interface{}{"url": putURL})
 
-	res, err := doDERPMapRequest(ctx, r.httpClient, http.MethodPut, putURL, updatedDM)
+	res, raw, err := doDERPMapRequest(ctx, r.httpClient, r.flavor, http.MethodPut, putURL, updatedDM)
 	if err != nil {
 		if isNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Update DERPMap error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Update DERPMap error", err)
 		return
 	}
 
-	newState := derpMapToResourceModel(res)
+	// The DERPMap must end up exactly as planned. If TACL merged the PUT into
+	// the existing map, regions removed from config are still there: replace
+	// the whole map instead (DELETE + POST). That briefly leaves no DERPMap at
+	// all, so it's refused under deletion_protection.
+	if extra := extraDERPRegions(updatedDM, res); len(extra) > 0 {
+		if plan.DeletionProtection.ValueBool() {
+			resp.Diagnostics.AddError("DERPMap regions not removed",
+				fmt.Sprintf("TACL kept region(s) %v after the update, and removing them means deleting and recreating the "+
+					"whole DERPMap, which deletion_protection forbids. Set deletion_protection = false to allow it, "+
+					"or remove the regions on the server.", extra))
+			return
+		}
+		tflog.Debug(ctx, "TACL kept removed DERP regions after PUT, replacing the DERPMap", map[string]interface{}{
+			"regions": extra,
+		})
+		var deleted bool
+		res, raw, deleted, err = r.replaceDERPMap(ctx, updatedDM)
+		if err != nil {
+			if deleted {
+				// The old map is gone and the new one wasn't written: drop
+				// it from state so the next plan recreates it.
+				resp.Diagnostics.AddError("DERPMap deleted but not recreated",
+					fmt.Sprintf("To remove region(s) %v the DERPMap was deleted, but writing the new one failed, so TACL "+
+						"currently has no DERPMap. It has been removed from state; the next apply recreates it.\n\n%s", extra, err))
+				resp.State.RemoveResource(ctx)
+				return
+			}
+			addAPIErrorDiagnostic(&resp.Diagnostics, "Replace DERPMap error", err)
+			return
+		}
+		if extra := extraDERPRegions(updatedDM, res); len(extra) > 0 {
+			resp.Diagnostics.AddError("DERPMap regions not removed",
+				fmt.Sprintf("TACL still reports region(s) %v after replacing the DERPMap; they are not in the configuration.", extra))
+			return
+		}
+	}
+
+	newState, err := derpMapToResourceModel(ctx, res)
+	if err != nil {
+		resp.Diagnostics.AddError("Convert DERPMap error", err.Error())
+		return
+	}
 	newState.ID = types.StringValue("derpmap")
+	newState.RawJSON = rawJSONOnUpdate(plan.RawJSON, r.debug, raw)
+	newState.DeletionProtection = plan.DeletionProtection
 
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -241,10 +403,17 @@ func (r *derpMapResource) Update(ctx context.Context, req resource.UpdateRequest
 // Delete => DELETE /derpmap
 // ------------------------------------------------------------------------------
 func (r *derpMapResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var protected types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &protected)...)
+	if resp.Diagnostics.HasError() || !checkDeletionProtection(&resp.Diagnostics, protected, false, "DERPMap") {
+		return
+	}
+
 	delURL := fmt.Sprintf("%s/derpmap", r.endpoint)
-	_, err := doDERPMapRequest(ctx, r.httpClient, http.MethodDelete, delURL, nil)
+	_, _, err := doDERPMapRequest(ctx, r.httpClient, r.flavor, http.MethodDelete, delURL, nil)
 	if err != nil && !isNotFound(err) {
-		resp.Diagnostics.AddError("Delete DERPMap error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Delete DERPMap error", err)
 		return
 	}
 	resp.State.RemoveResource(ctx)
@@ -254,40 +423,77 @@ func (r *derpMapResource) Delete(ctx context.Context, req resource.DeleteRequest
 // Helpers
 //------------------------------------------------------------------------------
 
-func doDERPMapRequest(ctx context.Context, client *http.Client, method, url string, payload *tsclient.ACLDERPMap) (*tsclient.ACLDERPMap, error) {
+// replaceDERPMap => DELETE /derpmap then POST dm, for servers whose PUT
+// merges. This isn't atomic: deleted reports whether the DELETE went through,
+// i.e. whether an error left the server with no DERPMap at all.
+func (r *derpMapResource) replaceDERPMap(ctx context.Context, dm *tsclient.ACLDERPMap) (res *tsclient.ACLDERPMap, raw []byte, deleted bool, err error) {
+	url := fmt.Sprintf("%s/derpmap", r.endpoint)
+	if _, _, err := doDERPMapRequest(ctx, r.httpClient, r.flavor, http.MethodDelete, url, nil); err != nil && !isNotFound(err) {
+		return nil, nil, false, err
+	}
+	res, raw, err = doDERPMapRequest(ctx, r.httpClient, r.flavor, http.MethodPost, url, dm)
+	return res, raw, true, err
+}
+
+// extraDERPRegions => IDs of regions in got that want doesn't have, sorted
+func extraDERPRegions(want, got *tsclient.ACLDERPMap) []int {
+	if got == nil {
+		return nil
+	}
+	var extra []int
+	for id := range got.Regions {
+		if _, ok := want.Regions[id]; !ok {
+			extra = append(extra, id)
+		}
+	}
+	sort.Ints(extra)
+	return extra
+}
+
+// doDERPMapRequest => JSON request against /derpmap. Returns the decoded map
+// and the raw response body.
+func doDERPMapRequest(ctx context.Context, client *http.Client, flavor, method, url string, payload *tsclient.ACLDERPMap) (*tsclient.ACLDERPMap, []byte, error) {
 	var body io.Reader
 	if payload != nil {
-		data, err := json.Marshal(payload)
+		data, err := marshalDERPMap(flavor, payload)
 		if err != nil {
-			return nil, fmt.Errorf("marshal payload: %w", err)
+			return nil, nil, fmt.Errorf("marshal payload: %w", err)
 		}
 		body = bytes.NewBuffer(data)
 	}
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
-		return nil, fmt.Errorf("DERPMap request creation error: %w", err)
+		return nil, nil, fmt.Errorf("DERPMap request creation error: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("DERPMap request error: %w", err)
+		return nil, nil, fmt.Errorf("DERPMap request error: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("NotFound")
+		return nil, nil, &NotFoundError{Message: "DERPMap not found"}
 	}
 	if resp.StatusCode >= 300 {
 		msg, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("DERPMap returned %d: %s", resp.StatusCode, string(msg))
+		return nil, nil, newAPIError(resp, msg)
 	}
 
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read DERPMap response: %w", err)
+	}
 	var dm tsclient.ACLDERPMap
-	if e := json.NewDecoder(resp.Body).Decode(&dm); e != nil {
-		return nil, fmt.Errorf("decode DERPMap: %w", e)
+	if len(bytes.TrimSpace(raw)) == 0 {
+		// e.g. DELETE => no body
+		return &dm, raw, nil
 	}
-	return &dm, nil
+	if e := json.Unmarshal(raw, &dm); e != nil {
+		return nil, nil, fmt.Errorf("decode DERPMap: %w", e)
+	}
+	return &dm, raw, nil
 }
 
 // resourceModelToDERPMap => convert typed TF plan => Tailscale struct
@@ -321,11 +527,12 @@ func resourceModelToDERPMap(model derpMapResourceModel) *tsclient.ACLDERPMap {
 	return &tsMap
 }
 
-// derpMapToResourceModel => convert Tailscale struct => typed TF state
-// derpMapToResourceModel => convert Tailscale struct => typed TF state
-func derpMapToResourceModel(dm *tsclient.ACLDERPMap) derpMapResourceModel {
+// derpMapToResourceModel => convert Tailscale struct => typed TF state.
+// Empty region_name/ipv4/ipv6 come back as null so an omitted value doesn't diff.
+// Stops early if ctx is cancelled.
+func derpMapToResourceModel(ctx context.Context, dm *tsclient.ACLDERPMap) (derpMapResourceModel, error) {
 	if dm == nil {
-		return derpMapResourceModel{}
+		return derpMapResourceModel{}, nil
 	}
 
 	// 1) Collect region IDs into a slice so we can sort them
@@ -339,6 +546,9 @@ func derpMapToResourceModel(dm *tsclient.ACLDERPMap) derpMapResourceModel {
 
 	// 2) Iterate over sorted region IDs
 	for _, rID := range regionIDs {
+		if err := ctx.Err(); err != nil {
+			return derpMapResourceModel{}, err
+		}
 		regionPtr := dm.Regions[rID]
 		if regionPtr == nil {
 			continue
@@ -364,8 +574,8 @@ func derpMapToResourceModel(dm *tsclient.ACLDERPMap) derpMapResourceModel {
 				Name:     types.StringValue(nptr.Name),
 				RegionID: types.Int64Value(int64(nptr.RegionID)),
 				HostName: types.StringValue(nptr.HostName),
-				IPv4:     types.StringValue(nptr.IPv4),
-				IPv6:     types.StringValue(nptr.IPv6),
+				IPv4:     stringOrNull(nptr.IPv4),
+				IPv6:     stringOrNull(nptr.IPv6),
 			})
 		}
 
@@ -373,7 +583,7 @@ func derpMapToResourceModel(dm *tsclient.ACLDERPMap) derpMapResourceModel {
 		regionList = append(regionList, derpMapRegionModel{
 			RegionID:   types.Int64Value(int64(rID)), // from map key
 			RegionCode: types.StringValue(regionPtr.RegionCode),
-			RegionName: types.StringValue(regionPtr.RegionName),
+			RegionName: stringOrNull(regionPtr.RegionName),
 			Nodes:      nodes,
 		})
 	}
@@ -382,5 +592,5 @@ func derpMapToResourceModel(dm *tsclient.ACLDERPMap) derpMapResourceModel {
 		ID:                 types.StringValue("derpmap"),
 		OmitDefaultRegions: types.BoolValue(dm.OmitDefaultRegions),
 		Regions:            regionList,
-	}
+	}, nil
 }
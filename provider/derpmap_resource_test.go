@@ -0,0 +1,270 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	tsclient "github.com/tailscale/tailscale-client-go/v2"
+)
+
+func TestDERPMapToResourceModel_NodeAddresses(t *testing.T) {
+	tests := []struct {
+		name           string
+		ipv4, ipv6     string
+		wantV4, wantV6 string
+		nullV4, nullV6 bool
+	}{
+		{name: "ipv4 only", ipv4: "192.0.2.10", wantV4: "192.0.2.10", nullV6: true},
+		{name: "ipv6 only", ipv6: "2001:db8::10", nullV4: true, wantV6: "2001:db8::10"},
+		{name: "both", ipv4: "192.0.2.10", ipv6: "2001:db8::10", wantV4: "192.0.2.10", wantV6: "2001:db8::10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dm := &tsclient.ACLDERPMap{Regions: map[int]*tsclient.ACLDERPRegion{
+				900: {RegionCode: "custom", RegionName: "Custom", Nodes: []*tsclient.ACLDERPNode{
+					{Name: "900a", RegionID: 900, HostName: "derp.example.com", IPv4: tt.ipv4, IPv6: tt.ipv6},
+				}},
+			}}
+			model, err := derpMapToResourceModel(context.Background(), dm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			node := model.Regions[0].Nodes[0]
+			if node.IPv4.IsNull() != tt.nullV4 || node.IPv4.ValueString() != tt.wantV4 {
+				t.Errorf("ipv4 = %v, want %q (null %v)", node.IPv4, tt.wantV4, tt.nullV4)
+			}
+			if node.IPv6.IsNull() != tt.nullV6 || node.IPv6.ValueString() != tt.wantV6 {
+				t.Errorf("ipv6 = %v, want %q (null %v)", node.IPv6, tt.wantV6, tt.nullV6)
+			}
+
+			// and back: a null address isn't sent
+			back := resourceModelToDERPMap(model).Regions[900].Nodes[0]
+			if back.IPv4 != tt.ipv4 || back.IPv6 != tt.ipv6 {
+				t.Errorf("round trip = %q/%q, want %q/%q", back.IPv4, back.IPv6, tt.ipv4, tt.ipv6)
+			}
+		})
+	}
+}
+
+// derpRegionList => a regions value for tr's schema; unset region
+// attributes are null
+func derpRegionList(tr *testResource, regions ...map[string]tftypes.Value) tftypes.Value {
+	tr.t.Helper()
+	objType := tr.schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	listType := objType.AttributeTypes["regions"].(tftypes.List)
+	vals := make([]tftypes.Value, 0, len(regions))
+	for _, region := range regions {
+		vals = append(vals, objectValue(tr.t, listType.ElementType, region, nil))
+	}
+	return tftypes.NewValue(listType, vals)
+}
+
+// derpRegions => a regions value for tr's schema, one region (with one node)
+// per ID
+func derpRegions(tr *testResource, ids ...int64) tftypes.Value {
+	tr.t.Helper()
+	objType := tr.schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	regionType := objType.AttributeTypes["regions"].(tftypes.List).ElementType.(tftypes.Object)
+	nodesType := regionType.AttributeTypes["nodes"].(tftypes.List)
+
+	regions := make([]map[string]tftypes.Value, 0, len(ids))
+	for _, id := range ids {
+		node := objectValue(tr.t, nodesType.ElementType, map[string]tftypes.Value{
+			"name":      tfString(fmt.Sprintf("%da", id)),
+			"region_id": tfNumber(id),
+			"host_name": tfString(fmt.Sprintf("derp%d.example.com", id)),
+			"ipv4":      tfString("192.0.2.10"),
+		}, nil)
+		regions = append(regions, map[string]tftypes.Value{
+			"region_id":   tfNumber(id),
+			"region_code": tfString(fmt.Sprintf("r%d", id)),
+			"region_name": tfString(fmt.Sprintf("Region %d", id)),
+			"nodes":       tftypes.NewValue(nodesType, []tftypes.Value{node}),
+		})
+	}
+	return derpRegionList(tr, regions...)
+}
+
+// serverRegions => region IDs in the fake server's DERPMap, sorted
+func serverRegions(srv *fakeTACL) []string {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	regions, _ := srv.derpmap["regions"].(map[string]interface{})
+	ids := make([]string, 0, len(regions))
+	for id := range regions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestDERPMapResource_UpdateRemovesRegion(t *testing.T) {
+	tests := []struct {
+		name        string
+		merge       bool // server PUT keeps regions missing from the body
+		wantDeletes int
+	}{
+		{name: "replacing PUT", merge: false, wantDeletes: 0},
+		{name: "merging PUT", merge: true, wantDeletes: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFakeTACL(t)
+			srv.derpMerge = tt.merge
+			r := newTestResource(t, newTestProvider(t, srv, nil), NewDERPMapResource())
+
+			state, diags := r.create(map[string]tftypes.Value{"regions": derpRegions(r, 900, 901)})
+			requireNoErrors(t, diags)
+
+			state, diags = r.update(state, map[string]tftypes.Value{"regions": derpRegions(r, 900)})
+			requireNoErrors(t, diags)
+			if got := serverRegions(srv); fmt.Sprint(got) != "[900]" {
+				t.Fatalf("server regions = %v, want [900]", got)
+			}
+			if got := srv.requested("DELETE /derpmap"); got != tt.wantDeletes {
+				t.Fatalf("DELETE /derpmap sent %d times, want %d", got, tt.wantDeletes)
+			}
+
+			var data derpMapResourceModel
+			requireNoErrors(t, state.Get(context.Background(), &data))
+			if len(data.Regions) != 1 || data.Regions[0].RegionID.ValueInt64() != 900 {
+				t.Fatalf("state regions = %+v, want only 900", data.Regions)
+			}
+		})
+	}
+}
+
+func TestDERPMapResource_UpdateReplaceDeletionProtection(t *testing.T) {
+	srv := newFakeTACL(t)
+	srv.derpMerge = true
+	r := newTestResource(t, newTestProvider(t, srv, nil), NewDERPMapResource())
+
+	state, diags := r.create(map[string]tftypes.Value{
+		"regions":             derpRegions(r, 900, 901),
+		"deletion_protection": tfBool(true),
+	})
+	requireNoErrors(t, diags)
+
+	_, diags = r.update(state, map[string]tftypes.Value{
+		"regions":             derpRegions(r, 900),
+		"deletion_protection": tfBool(true),
+	})
+	if !diags.HasError() || diags.Errors()[0].Summary() != "DERPMap regions not removed" {
+		t.Fatalf("errors = %v, want the replace refused", diags.Errors())
+	}
+	if got := srv.requested("DELETE /derpmap"); got != 0 {
+		t.Fatalf("DELETE /derpmap sent %d times under deletion_protection", got)
+	}
+	if got := serverRegions(srv); fmt.Sprint(got) != "[900 901]" {
+		t.Fatalf("server regions = %v, want both kept", got)
+	}
+}
+
+func TestDERPMapResource_UpdateReplaceRecreateFails(t *testing.T) {
+	srv := newFakeTACL(t)
+	srv.derpMerge = true
+	r := newTestResource(t, newTestProvider(t, srv, nil), NewDERPMapResource())
+
+	state, diags := r.create(map[string]tftypes.Value{"regions": derpRegions(r, 900, 901)})
+	requireNoErrors(t, diags)
+
+	srv.handle("POST /derpmap", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+	state, diags = r.update(state, map[string]tftypes.Value{"regions": derpRegions(r, 900)})
+	if !diags.HasError() || diags.Errors()[0].Summary() != "DERPMap deleted but not recreated" {
+		t.Fatalf("errors = %v, want the failed recreate reported", diags.Errors())
+	}
+	if !state.Raw.IsNull() {
+		t.Fatal("DERPMap kept in state although the server has none")
+	}
+}
+
+func TestDERPMapResource_ValidateUnnamedRegion(t *testing.T) {
+	r := newTestResource(t, &taclProvider{}, NewDERPMapResource())
+	tests := []struct {
+		name     string
+		region   tftypes.Value
+		wantWarn bool
+	}{
+		{"named", tfString("Seattle"), false},
+		{"unset", tftypes.NewValue(tftypes.String, nil), true},
+		{"empty", tfString(""), true},
+		{"unknown", tftypes.NewValue(tftypes.String, tftypes.UnknownValue), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := r.validate(map[string]tftypes.Value{
+				"regions": derpRegionList(r, map[string]tftypes.Value{
+					"region_id":   tfNumber(900),
+					"region_code": tfString("sea"),
+					"region_name": tt.region,
+				}),
+			})
+			requireNoErrors(t, diags)
+			warned := false
+			for _, d := range diags.Warnings() {
+				warned = warned || d.Summary() == "DERP region has no name"
+			}
+			if warned != tt.wantWarn {
+				t.Fatalf("warnings = %v, want the no-name warning %v", diags.Warnings(), tt.wantWarn)
+			}
+		})
+	}
+}
+
+func TestDERPMapToResourceModel_EmptyRegionName(t *testing.T) {
+	for name, want := range map[string]bool{"": true, "Seattle": false} {
+		dm := &tsclient.ACLDERPMap{Regions: map[int]*tsclient.ACLDERPRegion{
+			900: {RegionCode: "sea", RegionName: name},
+		}}
+		model, err := derpMapToResourceModel(context.Background(), dm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := model.Regions[0].RegionName; got.IsNull() != want || got.ValueString() != name {
+			t.Errorf("region_name %q read as %v, want null %v", name, got, want)
+		}
+	}
+}
+
+func TestDERPMapResource_ValidateDuplicates(t *testing.T) {
+	r := newTestResource(t, &taclProvider{}, NewDERPMapResource())
+	region := func(id int64, code string) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"region_id":   tfNumber(id),
+			"region_code": tfString(code),
+			"region_name": tfString(code),
+		}
+	}
+	tests := []struct {
+		name    string
+		regions []map[string]tftypes.Value
+		want    []string // error summaries
+	}{
+		{"distinct", []map[string]tftypes.Value{region(900, "sea"), region(901, "pdx")}, nil},
+		{"duplicate region_id", []map[string]tftypes.Value{region(900, "sea"), region(900, "pdx")}, []string{"Duplicate DERP region_id"}},
+		{"duplicate region_code", []map[string]tftypes.Value{region(900, "sea"), region(901, "sea")}, []string{"Duplicate DERP region_code"}},
+		{"both duplicated", []map[string]tftypes.Value{region(900, "sea"), region(900, "sea")}, []string{"Duplicate DERP region_id", "Duplicate DERP region_code"}},
+		{"unknown id", []map[string]tftypes.Value{
+			region(900, "sea"),
+			{"region_id": tftypes.NewValue(tftypes.Number, tftypes.UnknownValue), "region_code": tfString("pdx")},
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := r.validate(map[string]tftypes.Value{"regions": derpRegionList(r, tt.regions...)})
+			var got []string
+			for _, d := range diags.Errors() {
+				got = append(got, d.Summary())
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("errors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
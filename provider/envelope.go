@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// envelopeMetaKeys => keys that may sit next to "data" in an enveloped
+// response. Anything else (e.g. a list's "next" cursor) means the body isn't
+// a plain envelope and is left alone.
+var envelopeMetaKeys = map[string]bool{
+	"meta":       true,
+	"links":      true,
+	"status":     true,
+	"success":    true,
+	"message":    true,
+	"request_id": true,
+	"requestId":  true,
+}
+
+// envelopeTransport => RoundTripper that unwraps { "data": ... } envelopes
+// from successful responses, so every decode site sees the bare object no
+// matter which TACL version answered. With detectErrorBodies, a successful
+// response carrying an error object instead (see isErrorBody) fails the
+// request with an *APIError.
+type envelopeTransport struct {
+	base              http.RoundTripper
+	detectErrorBodies bool
+}
+
+func (t *envelopeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.base.RoundTrip(req)
+	if err != nil || res.StatusCode < 200 || res.StatusCode >= 300 || res.Body == nil {
+		return res, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if t.detectErrorBodies && isErrorBody(body) {
+		return nil, newAPIError(res, body)
+	}
+
+	if unwrapped, ok := unwrapEnvelope(body); ok {
+		body = unwrapped
+		res.ContentLength = int64(len(body))
+		if res.Header.Get("Content-Length") != "" {
+			res.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		}
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	return res, nil
+}
+
+// unwrapEnvelope => the "data" member of an enveloped body. ok is false for
+// anything else (bare objects, arrays, HuJSON, paginated lists), which is
+// returned unchanged by the caller.
+func unwrapEnvelope(body []byte) ([]byte, bool) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return body, false
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &fields); err != nil {
+		return body, false
+	}
+	data, ok := fields["data"]
+	if !ok {
+		return body, false
+	}
+	for key := range fields {
+		if key != "data" && !envelopeMetaKeys[key] {
+			return body, false
+		}
+	}
+	return data, true
+}
+
+// isErrorBody => a JSON object that reports a failure: a non-empty "error"
+// member (string or object), or "success": false. Bare objects, arrays and
+// HuJSON never match.
+func isErrorBody(body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return false
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(trimmed, &fields); err != nil {
+		return false
+	}
+	switch e := fields["error"].(type) {
+	case string:
+		if e != "" {
+			return true
+		}
+	case map[string]interface{}:
+		return true
+	}
+	success, ok := fields["success"].(bool)
+	return ok && !success
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestUnwrapEnvelope(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		want   string
+		wantOK bool
+	}{
+		{name: "bare object", body: `{"name":"eng"}`, want: `{"name":"eng"}`},
+		{name: "bare array", body: `[{"name":"eng"}]`, want: `[{"name":"eng"}]`},
+		{name: "data", body: `{"data":{"name":"eng"}}`, want: `{"name":"eng"}`, wantOK: true},
+		{name: "data and meta", body: `{"data":{"name":"eng"},"meta":{"version":"1"}}`, want: `{"name":"eng"}`, wantOK: true},
+		{name: "data array", body: ` {"data":[1,2]} `, want: `[1,2]`, wantOK: true},
+		{name: "paginated list", body: `{"data":[1,2],"next":"abc"}`, want: `{"data":[1,2],"next":"abc"}`},
+		{name: "object with a data field", body: `{"name":"eng","data":"x"}`, want: `{"name":"eng","data":"x"}`},
+		{name: "hujson", body: "{\"data\":{}, // comment\n}", want: "{\"data\":{}, // comment\n}"},
+		{name: "empty", body: ``, want: ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := unwrapEnvelope([]byte(tt.body))
+			if ok != tt.wantOK || string(got) != tt.want {
+				t.Fatalf("unwrapEnvelope(%s) = %s, %v; want %s, %v", tt.body, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestEnvelopeTransport_ResponseShapes(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"bare object", `{"name":"eng","members":[]}`, `{"name":"eng","members":[]}`},
+		{"data", `{"data":{"name":"eng","members":[]}}`, `{"name":"eng","members":[]}`},
+		{"data and meta", `{"data":{"name":"eng"},"meta":{"page":1}}`, `{"name":"eng"}`},
+		{"data and next", `{"data":[{"name":"eng"}],"next":"cursor"}`, `{"data":[{"name":"eng"}],"next":"cursor"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFakeTACL(t)
+			srv.handle("GET /groups/eng", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.body))
+			})
+			p := newTestProvider(t, srv, nil)
+
+			got, err := doSingleObjectReq(context.Background(), p.httpClient, http.MethodGet, srv.URL+"/groups/eng", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("body = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvelopeTransport_DetectErrorBodies(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		isErrBody bool
+	}{
+		{"error string", `{"error":"group not found"}`, true},
+		{"error object", `{"error":{"message":"boom"}}`, true},
+		{"success false", `{"success":false,"message":"denied"}`, true},
+		{"empty error", `{"name":"eng","error":""}`, false},
+		{"success true", `{"data":{"name":"eng"},"success":true}`, false},
+		{"bare object", `{"name":"eng"}`, false},
+	}
+	for _, tt := range tests {
+		for _, detect := range []bool{true, false} {
+			name := tt.name + "/detect off"
+			if detect {
+				name = tt.name + "/detect on"
+			}
+			t.Run(name, func(t *testing.T) {
+				srv := newFakeTACL(t)
+				srv.handle("GET /groups/eng", func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write([]byte(tt.body))
+				})
+				p := newTestProvider(t, srv, map[string]tftypes.Value{"detect_error_bodies": tfBool(detect)})
+
+				_, err := doSingleObjectReq(context.Background(), p.httpClient, http.MethodGet, srv.URL+"/groups/eng", nil)
+				if !detect || !tt.isErrBody {
+					if err != nil {
+						t.Fatalf("200 response failed: %v", err)
+					}
+					return
+				}
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) {
+					t.Fatalf("err = %v (%T), want an *APIError", err, err)
+				}
+				if apiErr.StatusCode != http.StatusOK {
+					t.Fatalf("status = %d, want 200", apiErr.StatusCode)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"encoding/json"
+
+	tsclient "github.com/tailscale/tailscale-client-go/v2"
+)
+
+// Backend flavors the provider can talk to. TACL fronting Headscale expects
+// some objects in Headscale's own shapes instead of Tailscale's. Only the
+// DERPMap differs today; autoApprovers use the same routes/exitNode keys in
+// both, so they go out unchanged.
+const (
+	flavorTailscale = "tailscale"
+	flavorHeadscale = "headscale"
+)
+
+// headscaleDERPNode / headscaleDERPRegion / headscaleDERPMap => the same
+// fields as tsclient's DERPMap types, with the all-lowercase keys Headscale
+// uses in its derp.yaml/JSON. Field sets must stay identical so the types
+// convert directly.
+type headscaleDERPNode struct {
+	Name     string `json:"name"`
+	RegionID int    `json:"regionid"`
+	HostName string `json:"hostname"`
+	CertName string `json:"certname,omitempty"`
+	IPv4     string `json:"ipv4,omitempty"`
+	IPv6     string `json:"ipv6,omitempty"`
+	STUNPort int    `json:"stunport,omitempty"`
+	STUNOnly bool   `json:"stunonly,omitempty"`
+	DERPPort int    `json:"derpport,omitempty"`
+}
+
+type headscaleDERPRegion struct {
+	RegionID   int                  `json:"regionid"`
+	RegionCode string               `json:"regioncode"`
+	RegionName string               `json:"regionname"`
+	Avoid      bool                 `json:"avoid,omitempty"`
+	Nodes      []*headscaleDERPNode `json:"nodes"`
+}
+
+type headscaleDERPMap struct {
+	Regions            map[int]*headscaleDERPRegion `json:"regions"`
+	OmitDefaultRegions bool                         `json:"omitdefaultregions,omitempty"`
+}
+
+// marshalDERPMap => JSON body for /derpmap in the backend's shape. Decoding
+// needs no counterpart: encoding/json matches keys case-insensitively, so
+// tsclient.ACLDERPMap reads either shape.
+func marshalDERPMap(flavor string, dm *tsclient.ACLDERPMap) ([]byte, error) {
+	if flavor != flavorHeadscale {
+		return json.Marshal(dm)
+	}
+
+	hs := headscaleDERPMap{
+		Regions:            make(map[int]*headscaleDERPRegion, len(dm.Regions)),
+		OmitDefaultRegions: dm.OmitDefaultRegions,
+	}
+	for id, region := range dm.Regions {
+		if region == nil {
+			continue
+		}
+		hsRegion := &headscaleDERPRegion{
+			RegionID:   region.RegionID,
+			RegionCode: region.RegionCode,
+			RegionName: region.RegionName,
+			Avoid:      region.Avoid,
+		}
+		for _, n := range region.Nodes {
+			if n == nil {
+				continue
+			}
+			hsNode := headscaleDERPNode(*n)
+			hsRegion.Nodes = append(hsRegion.Nodes, &hsNode)
+		}
+		hs.Regions[id] = hsRegion
+	}
+	return json.Marshal(hs)
+}
@@ -0,0 +1,426 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// TaclGrant => one grant as TACL stores it under /grants (Tailscale's grants
+// syntax, the successor to classic ACL rules)
+type TaclGrant struct {
+	Src        []string               `json:"src"`
+	Dst        []string               `json:"dst"`
+	IP         []string               `json:"ip,omitempty"`
+	App        map[string]interface{} `json:"app,omitempty"`
+	SrcPosture []string               `json:"srcPosture,omitempty"`
+	Via        []string               `json:"via,omitempty"`
+}
+
+// TaclGrantResponse => server's shape for a single grant
+type TaclGrantResponse struct {
+	ID string `json:"id"`
+	TaclGrant
+
+	auditFields // updatedBy/updatedAt, if TACL records them
+}
+
+var (
+	_ resource.Resource                   = &grantResource{}
+	_ resource.ResourceWithConfigure      = &grantResource{}
+	_ resource.ResourceWithValidateConfig = &grantResource{}
+)
+
+// NewGrantResource => constructor for "tacl_grant"
+func NewGrantResource() resource.Resource {
+	return &grantResource{}
+}
+
+type grantResource struct {
+	httpClient     *http.Client
+	endpoint       string
+	logMasks       []string // provider secrets, masked in tflog (see maskLogCtx)
+	normalizeLists bool     // provider's normalize_lists
+	readAfterWrite readAfterWrite
+}
+
+type grantResourceModel struct {
+	ID         types.String   `tfsdk:"id"`
+	Src        []types.String `tfsdk:"src"`
+	Dst        []types.String `tfsdk:"dst"`
+	IP         []types.String `tfsdk:"ip"`
+	AppJSON    types.String   `tfsdk:"app_json"`
+	SrcPosture []types.String `tfsdk:"src_posture"`
+	Via        []types.String `tfsdk:"via"`
+
+	LastModifiedBy types.String `tfsdk:"last_modified_by"` // updatedBy, if TACL records it
+	LastModifiedAt types.String `tfsdk:"last_modified_at"` // updatedAt, if TACL records it
+}
+
+func (r *grantResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p, ok := req.ProviderData.(*taclProvider)
+	if !ok {
+		return
+	}
+	r.httpClient = p.httpClient
+	r.endpoint = p.endpoint
+	r.logMasks = p.logMasks
+	r.normalizeLists = p.normalizeLists
+	r.readAfterWrite = p.readAfterWrite
+}
+
+func (r *grantResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_grant"
+}
+
+func (r *grantResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a single grant by stable ID in TACL’s /grants. Grants are Tailscale's successor to " +
+			"classic ACL rules; each one needs `ip`, `app_json`, or both.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Stable UUID of the grant.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"src": schema.ListAttribute{
+				Description: "Sources (users, groups, tags, autogroups, hosts, CIDRs).",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					nonEmptyListValidator{},
+				},
+			},
+			"dst": schema.ListAttribute{
+				Description: "Destinations (tags, autogroups, hosts, CIDRs). Unlike ACL dst, no ports here; use `ip`.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					nonEmptyListValidator{},
+				},
+			},
+			"ip": schema.ListAttribute{
+				Description: "Network capabilities granted, e.g. ['*'], ['tcp:443'] or ['udp:53', 'tcp:22-23'].",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"app_json": schema.StringAttribute{
+				Description: "Optional JSON object for `app` (application capabilities), e.g. " +
+					"`jsonencode({\"tailscale.com/cap/webui\" = [{}]})`. Formatting and key order don't cause a diff.",
+				Optional: true,
+				Validators: []validator.String{
+					jsonObjectValidator{},
+				},
+			},
+			"src_posture": schema.ListAttribute{
+				Description: "Optional posture conditions the source device must meet, as 'posture:<name>' references (sent as srcPosture).",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					postureRefsValidator{},
+				},
+			},
+			"via": schema.ListAttribute{
+				Description: "Optional tags of exit nodes, subnet routers or app connectors the traffic must route through.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"last_modified_by": lastModifiedByAttribute(),
+			"last_modified_at": lastModifiedAtAttribute(),
+		},
+	}
+}
+
+// ValidateConfig => a grant without ip or app grants nothing; TACL rejects it
+func (r *grantResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var ip types.List
+	var app types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ip"), &ip)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("app_json"), &app)...)
+	if resp.Diagnostics.HasError() || ip.IsUnknown() || app.IsUnknown() {
+		return
+	}
+	if (ip.IsNull() || len(ip.Elements()) == 0) && app.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("ip"), "Grant has no capabilities",
+			"At least one of `ip` or `app_json` must be set.")
+	}
+}
+
+// CREATE => POST /grants
+func (r *grantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var plan grantResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	grant, err := r.grantPayload(plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid app_json", err.Error())
+		return
+	}
+
+	// The Idempotency-Key lets the server dedupe a retried POST
+	postURL := fmt.Sprintf("%s/grants", r.endpoint)
+	ctx, idemKey := withIdempotencyKey(ctx)
+	tflog.Debug(ctx, "Creating grant", map[string]interface{}{
+		"url":             postURL,
+		"payload":         redactForLog(grant),
+		"idempotency_key": idemKey,
+	})
+
+	body, err := doGrantRequest(ctx, r.httpClient, http.MethodPost, postURL, grant)
+	if err != nil {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Create grant error", err)
+		return
+	}
+
+	var created TaclGrantResponse
+	if e := json.Unmarshal(body, &created); e != nil {
+		resp.Diagnostics.AddError("Parse create response error", e.Error())
+		return
+	}
+
+	// Optionally confirm the new grant is readable (replication lag)
+	getURL := fmt.Sprintf("%s/grants/%s", r.endpoint, created.ID)
+	fetched, err := awaitCreated(ctx, r.readAfterWrite, func() ([]byte, error) {
+		return doGrantRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
+	})
+	if err != nil {
+		addReadAfterWriteWarning(&resp.Diagnostics, "grant", err)
+	} else if fetched != nil {
+		// fresh value: unmarshalling over created would merge app maps
+		created = TaclGrantResponse{}
+		if e := json.Unmarshal(fetched, &created); e != nil {
+			resp.Diagnostics.AddError("Parse read response error", e.Error())
+			return
+		}
+	}
+
+	setGrantState(&plan, created)
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// READ => GET /grants/:id
+func (r *grantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var data grantResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.ID.ValueString()
+	if id == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	getURL := fmt.Sprintf("%s/grants/%s", r.endpoint, id)
+	tflog.Debug(ctx, "Reading grant", map[string]interface{}{
+		"url": getURL,
+		"id":  id,
+	})
+
+	body, err := doGrantRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read grant error", err)
+		return
+	}
+
+	var fetched TaclGrantResponse
+	if e := json.Unmarshal(body, &fetched); e != nil {
+		resp.Diagnostics.AddError("Parse read response error", e.Error())
+		return
+	}
+
+	setGrantState(&data, fetched)
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// UPDATE => PUT /grants => payload { "id":"...", "grant": {...} }
+func (r *grantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var old grantResourceModel
+	diags := req.State.Get(ctx, &old)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan grantResourceModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = old.ID
+	id := plan.ID.ValueString()
+	if id == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	grant, err := r.grantPayload(plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid app_json", err.Error())
+		return
+	}
+	payload := map[string]interface{}{
+		"id":    id,
+		"grant": grant,
+	}
+
+	putURL := fmt.Sprintf("%s/grants", r.endpoint)
+	tflog.Debug(ctx, "Updating grant", map[string]interface{}{
+		"url":     putURL,
+		"payload": redactForLog(payload),
+	})
+
+	body, err := doGrantRequest(ctx, r.httpClient, http.MethodPut, putURL, payload)
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Update grant error", err)
+		return
+	}
+
+	var updated TaclGrantResponse
+	if e := json.Unmarshal(body, &updated); e != nil {
+		resp.Diagnostics.AddError("Parse update response error", e.Error())
+		return
+	}
+
+	setGrantState(&plan, updated)
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// DELETE => DELETE /grants => { "id":"..." }
+func (r *grantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var data grantResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.ID.ValueString()
+	if id == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	delPayload := map[string]string{"id": id}
+	delURL := fmt.Sprintf("%s/grants", r.endpoint)
+	tflog.Debug(ctx, "Deleting grant", map[string]interface{}{
+		"url":     delURL,
+		"payload": redactForLog(delPayload),
+	})
+
+	_, err := doGrantRequest(ctx, r.httpClient, http.MethodDelete, delURL, delPayload)
+	if err != nil && !isNotFound(err) {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Delete grant error", err)
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+// grantPayload => plan => TaclGrant. app_json is already checked to be an
+// object at plan time, so a parse error here means it was unknown until apply.
+func (r *grantResource) grantPayload(plan grantResourceModel) (TaclGrant, error) {
+	grant := TaclGrant{
+		Src:        listPayload(plan.Src, r.normalizeLists),
+		Dst:        listPayload(plan.Dst, r.normalizeLists),
+		IP:         listPayload(plan.IP, r.normalizeLists),
+		SrcPosture: toGoStringSlice(plan.SrcPosture),
+		Via:        listPayload(plan.Via, r.normalizeLists),
+	}
+	if app := plan.AppJSON.ValueString(); app != "" {
+		if err := json.Unmarshal([]byte(app), &grant.App); err != nil {
+			return TaclGrant{}, err
+		}
+	}
+	return grant, nil
+}
+
+// setGrantState => copy the server's grant into data, keeping the configured
+// spelling where it's equivalent (see normalizedOrPrior / appJSONOrPrior)
+func setGrantState(data *grantResourceModel, g TaclGrantResponse) {
+	data.ID = types.StringValue(g.ID)
+	data.Src = normalizedOrPrior(g.Src, data.Src)
+	data.Dst = normalizedOrPrior(g.Dst, data.Dst)
+	data.IP = optionalListOrPrior(g.IP, data.IP)
+	data.SrcPosture = optionalListOrPrior(g.SrcPosture, data.SrcPosture)
+	data.Via = optionalListOrPrior(g.Via, data.Via)
+	data.LastModifiedBy, data.LastModifiedAt = g.auditValues()
+	if g.App != nil {
+		data.AppJSON = appJSONOrPrior(g.App, data.AppJSON)
+	} else {
+		data.AppJSON = types.StringNull()
+	}
+}
+
+func doGrantRequest(ctx context.Context, client *http.Client, method, url string, payload interface{}) ([]byte, error) {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("grant request marshal error: %w", err)
+		}
+		body = bytes.NewBuffer(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create grant request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setIdempotencyKey(ctx, req)
+
+	respHTTP, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("grant request error: %w", err)
+	}
+	defer respHTTP.Body.Close()
+
+	if respHTTP.StatusCode == 404 {
+		return nil, &NotFoundError{Message: "grant not found"}
+	}
+	if respHTTP.StatusCode >= 300 {
+		msg, _ := io.ReadAll(respHTTP.Body)
+		return nil, newAPIError(respHTTP, msg)
+	}
+
+	return io.ReadAll(respHTTP.Body)
+}
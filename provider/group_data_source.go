@@ -27,12 +27,15 @@ func NewGroupDataSource() datasource.DataSource {
 type groupDataSource struct {
 	httpClient *http.Client
 	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
 }
 
 type groupDataSourceModel struct {
 	ID      types.String   `tfsdk:"id"`
 	Name    types.String   `tfsdk:"name"`
 	Members []types.String `tfsdk:"members"`
+
+	FailIfMissing types.Bool `tfsdk:"fail_if_missing"`
 }
 
 // Configure gets a handle to the provider’s httpClient & endpoint.
@@ -44,8 +47,9 @@ func (d *groupDataSource) Configure(ctx context.Context, req datasource.Configur
 	if !ok {
 		return
 	}
-	d.httpClient = provider.httpClient
+	d.httpClient = provider.dsHTTPClient
 	d.endpoint = provider.endpoint
+	d.logMasks = provider.logMasks
 }
 
 // Metadata sets the data source name, e.g. "tacl_group".
@@ -57,6 +61,10 @@ func (d *groupDataSource) Metadata(ctx context.Context, req datasource.MetadataR
 func (d *groupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"fail_if_missing": schema.BoolAttribute{
+				Description: "If true, a lookup that finds nothing is an error. Defaults to false: a warning, with attributes left null.",
+				Optional:    true,
+			},
 			"id": schema.StringAttribute{
 				Description: "Always the same as `name` for reference.",
 				Computed:    true,
@@ -65,8 +73,8 @@ func (d *groupDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				Description: "Name of the group to look up.",
 				Required:    true,
 			},
-			"members": schema.ListAttribute{
-				Description: "List of group members.",
+			"members": schema.SetAttribute{
+				Description: "Set of group members, matching tacl_group's members.",
 				Computed:    true,
 				ElementType: types.StringType,
 			},
@@ -76,6 +84,7 @@ func (d *groupDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 
 // Read => GET /groups/:name
 func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = maskLogCtx(ctx, d.logMasks)
 	var data groupDataSourceModel
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -92,12 +101,11 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	respBody, err := doDSRequest(ctx, d.httpClient, http.MethodGet, getURL, nil)
 	if err != nil {
-		if IsNotFound(err) {
-			// The group doesn't exist
-			resp.Diagnostics.AddWarning("Group not found", fmt.Sprintf("No group named '%s' found.", name))
+		if isNotFound(err) {
+			addDSMissingDiagnostic(&resp.Diagnostics, data.FailIfMissing, "Group", fmt.Sprintf("named %q", name))
 			return
 		}
-		resp.Diagnostics.AddError("Error reading group data source", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Error reading group data source", err)
 		return
 	}
 
@@ -111,7 +119,7 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	data.ID = types.StringValue(name)
 	data.Name = types.StringValue(name)
 	if members, ok := fetched["members"].([]interface{}); ok {
-		data.Members = toStringTypeSlice(members)
+		data.Members = toStringSetElements(members)
 	}
 
 	diags = resp.State.Set(ctx, &data)
@@ -146,7 +154,7 @@ func doDSRequest(ctx context.Context, client *http.Client, method, url string, p
 	}
 	if resp.StatusCode >= 300 {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("TACL returned HTTP %d: %s", resp.StatusCode, string(respBody))
+		return nil, newAPIError(resp, respBody)
 	}
 
 	respBody, err := io.ReadAll(resp.Body)
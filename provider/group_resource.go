@@ -7,16 +7,22 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
-	_ resource.Resource              = &groupResource{}
-	_ resource.ResourceWithConfigure = &groupResource{}
+	_ resource.Resource                   = &groupResource{}
+	_ resource.ResourceWithConfigure      = &groupResource{}
+	_ resource.ResourceWithValidateConfig = &groupResource{}
+	_ resource.ResourceWithUpgradeState   = &groupResource{}
 )
 
 // NewGroupResource is the constructor for the group resource.
@@ -27,12 +33,14 @@ func NewGroupResource() resource.Resource {
 type groupResource struct {
 	httpClient *http.Client
 	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
 }
 
 type groupResourceModel struct {
-	ID      types.String   `tfsdk:"id"`   // We'll store the group's name as ID
-	Name    types.String   `tfsdk:"name"` // Required
-	Members []types.String `tfsdk:"members"`
+	ID       types.String   `tfsdk:"id"`        // We'll store the group's name as ID
+	ServerID types.String   `tfsdk:"server_id"` // the server's own ID, if it reports one
+	Name     types.String   `tfsdk:"name"`      // Required
+	Members  []types.String `tfsdk:"members"`
 }
 
 // Configure extracts the provider's httpClient and endpoint
@@ -46,6 +54,7 @@ func (r *groupResource) Configure(ctx context.Context, req resource.ConfigureReq
 	}
 	r.httpClient = provider.httpClient
 	r.endpoint = provider.endpoint
+	r.logMasks = provider.logMasks
 }
 
 // Metadata sets the resource type name, e.g. "tacl_group".
@@ -56,26 +65,114 @@ func (r *groupResource) Metadata(ctx context.Context, req resource.MetadataReque
 // Schema defines the resource attributes.
 func (r *groupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// v1 => server_id holds the server's ID; id stays the name
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Internal ID, same as `name`.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					nameIDPlanModifier{},
+				},
+			},
+			"server_id": schema.StringAttribute{
+				Description: "ID TACL reports for the group, e.g. a UUID on newer servers. Null for servers that key groups by name only.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					nameIDPlanModifier{},
+				},
 			},
 			"name": schema.StringAttribute{
 				Description: "Name of the group.",
 				Required:    true,
 			},
-			"members": schema.ListAttribute{
-				Description: "List of group members (strings: emails, other groups, etc.).",
+			"members": schema.SetAttribute{
+				Description: "Set of group members (strings: emails, other groups, etc.). Order doesn't matter. Group references must use the `group:<name>` form, and a group can't list itself. An empty set is sent to TACL as `[]`; omit the attribute to leave members unset.",
 				Optional:    true,
 				ElementType: types.StringType,
+				Validators: []validator.Set{
+					groupMembersValidator{},
+				},
 			},
 		},
 	}
 }
 
+// groupResourceModelV0 => tacl_group state as written by schema version 0
+type groupResourceModelV0 struct {
+	ID      types.String   `tfsdk:"id"`
+	Name    types.String   `tfsdk:"name"`
+	Members []types.String `tfsdk:"members"`
+}
+
+// groupResourceSchemaV0 => tacl_group schema version 0, frozen; don't edit it along
+// with Schema.
+func groupResourceSchemaV0() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":      schema.StringAttribute{Computed: true},
+			"name":    schema.StringAttribute{Required: true},
+			"members": schema.ListAttribute{Optional: true, ElementType: types.StringType},
+		},
+	}
+}
+
+// UpgradeState => v0 had no server_id. Re-read by name so state picks up the
+// server's ID (a UUID on newer TACL); id stays the name. v0 members were a
+// list; duplicates are dropped now that they're a set.
+func (r *groupResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	priorSchema := groupResourceSchemaV0()
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior groupResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				name := prior.Name.ValueString()
+				data := groupResourceModel{
+					ID:       types.StringValue(name),
+					ServerID: upgradeServerID(ctx, r.httpClient, fmt.Sprintf("%s/groups/%s", r.endpoint, name), name),
+					Name:     prior.Name,
+					Members:  prior.Members,
+				}
+				if prior.Members != nil {
+					data.Members = toTerraformStringSlice(uniqueSortedStrings(toStringSlice(prior.Members)))
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
+// ValidateConfig => a group can't be one of its own members
+func (r *groupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var name types.String
+	var members types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("members"), &members)...)
+	if resp.Diagnostics.HasError() || name.IsNull() || name.IsUnknown() || members.IsNull() || members.IsUnknown() {
+		return
+	}
+
+	self := strings.TrimPrefix(name.ValueString(), "group:")
+	for _, elem := range members.Elements() {
+		m, ok := elem.(types.String)
+		if !ok || m.IsNull() || m.IsUnknown() {
+			continue
+		}
+		if v := m.ValueString(); v == self || v == "group:"+self {
+			resp.Diagnostics.AddAttributeError(path.Root("members").AtSetValue(m), "Group references itself",
+				fmt.Sprintf("Group %q can't list itself (%q) as a member.", name.ValueString(), v))
+		}
+	}
+}
+
 // Create => POST /groups
 func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data groupResourceModel
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -83,20 +180,17 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	payload := map[string]interface{}{
-		"name":    data.Name.ValueString(),
-		"members": toStringSlice(data.Members),
-	}
+	payload := groupPayload(data)
 
 	postURL := fmt.Sprintf("%s/groups", r.endpoint)
 	tflog.Debug(ctx, "Creating group via Tacl", map[string]interface{}{
 		"url":     postURL,
-		"payload": payload,
+		"payload": redactForLog(payload),
 	})
 
 	body, err := doRequest(ctx, r.httpClient, http.MethodPost, postURL, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Create group error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Create group error", err)
 		return
 	}
 
@@ -106,8 +200,8 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	// For simplicity, just set ID = name
 	data.ID = data.Name
+	data.ServerID = serverObjectID(body)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -115,6 +209,7 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 
 // Read => GET /groups/:name
 func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data groupResourceModel
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -131,13 +226,13 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	body, err := doRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
 	if err != nil {
-		if IsNotFound(err) {
+		if isNotFound(err) {
 			// If 404, group no longer exists => remove from state
 			tflog.Warn(ctx, "Group not found, removing from state", map[string]interface{}{"name": name})
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Read group error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read group error", err)
 		return
 	}
 
@@ -148,11 +243,10 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	data.ID = types.StringValue(name)
+	data.ServerID = serverObjectID(body)
 	data.Name = types.StringValue(name)
 
-	if members, ok := fetched["members"].([]interface{}); ok {
-		data.Members = toStringTypeSlice(members)
-	}
+	data.Members = membersFromResponse(data.Members, fetched)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -160,6 +254,7 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // Update => PUT /groups
 func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data groupResourceModel
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -167,25 +262,22 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	payload := map[string]interface{}{
-		"name":    data.Name.ValueString(),
-		"members": toStringSlice(data.Members),
-	}
+	payload := groupPayload(data)
 
 	putURL := fmt.Sprintf("%s/groups", r.endpoint)
 	tflog.Debug(ctx, "Updating group via Tacl", map[string]interface{}{
 		"url":     putURL,
-		"payload": payload,
+		"payload": redactForLog(payload),
 	})
 
 	body, err := doRequest(ctx, r.httpClient, http.MethodPut, putURL, payload)
 	if err != nil {
-		if IsNotFound(err) {
+		if isNotFound(err) {
 			// If TACL says 404, group doesn't exist => remove from state
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Update group error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Update group error", err)
 		return
 	}
 
@@ -195,11 +287,10 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	if members, ok := updated["members"].([]interface{}); ok {
-		data.Members = toStringTypeSlice(members)
-	}
+	data.Members = membersFromResponse(data.Members, updated)
 
 	data.ID = data.Name
+	data.ServerID = serverObjectID(body)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -207,6 +298,7 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 // Delete => DELETE /groups
 func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data groupResourceModel
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -225,19 +317,46 @@ func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	_, err := doRequest(ctx, r.httpClient, http.MethodDelete, delURL, payload)
-	if err != nil {
-		if IsNotFound(err) {
-			// Already gone
-		} else {
-			resp.Diagnostics.AddError("Delete group error", err.Error())
-			return
-		}
+	if err != nil && !isNotFound(err) {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Delete group error", err)
+		return
 	}
 
 	// Remove from state
 	resp.State.RemoveResource(ctx)
 }
 
+// groupPayload => omitted members are left out of the body, while an
+// explicitly empty set is sent as []. Members are sorted so the body doesn't
+// depend on config order.
+func groupPayload(data groupResourceModel) map[string]interface{} {
+	payload := map[string]interface{}{
+		"name": data.Name.ValueString(),
+	}
+	if data.Members != nil {
+		payload["members"] = uniqueSortedStrings(toStringSlice(data.Members))
+	}
+	return payload
+}
+
+// membersFromResponse => TACL reports "no members" as [] (or null) whether the
+// field was omitted or set to an empty list, so keep whichever form is already
+// in state/plan. A response without the field leaves prior untouched.
+func membersFromResponse(prior []types.String, obj map[string]interface{}) []types.String {
+	raw, present := obj["members"]
+	if !present {
+		return prior
+	}
+	members, _ := raw.([]interface{})
+	if len(members) > 0 {
+		return toStringSetElements(members)
+	}
+	if prior == nil {
+		return nil
+	}
+	return []types.String{}
+}
+
 // Common doRequest method
 func doRequest(ctx context.Context, client *http.Client, method, url string, payload interface{}) ([]byte, error) {
 	var body io.Reader
@@ -267,7 +386,7 @@ func doRequest(ctx context.Context, client *http.Client, method, url string, pay
 	}
 	if resp.StatusCode >= 300 {
 		msg, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("tacl returned %d: %s", resp.StatusCode, string(msg))
+		return nil, newAPIError(resp, msg)
 	}
 
 	respBody, err := io.ReadAll(resp.Body)
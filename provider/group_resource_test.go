@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestGroupResource_CRUD(t *testing.T) {
+	srv := newFakeTACL(t)
+	r := newTestResource(t, newTestProvider(t, srv, nil), NewGroupResource())
+
+	state, diags := r.create(map[string]tftypes.Value{
+		"name":    tfString("eng"),
+		"members": tfStrings(setOf, "bob@example.com", "alice@example.com"),
+	})
+	requireNoErrors(t, diags)
+	if got := stateString(t, state, "id"); got != "eng" {
+		t.Fatalf("id = %q, want the name", got)
+	}
+	if got := srv.named["groups"]["eng"]["members"]; fmt.Sprint(got) != "[alice@example.com bob@example.com]" {
+		t.Fatalf("server members = %v", got)
+	}
+
+	state, diags = r.read(state)
+	requireNoErrors(t, diags)
+	if state.Raw.IsNull() {
+		t.Fatal("group dropped from state on read")
+	}
+
+	state, diags = r.update(state, map[string]tftypes.Value{
+		"name":    tfString("eng"),
+		"members": tfStrings(setOf, "carol@example.com"),
+	})
+	requireNoErrors(t, diags)
+	if got := srv.named["groups"]["eng"]["members"]; fmt.Sprint(got) != "[carol@example.com]" {
+		t.Fatalf("server members after update = %v", got)
+	}
+
+	gone, diags := r.delete(state)
+	requireNoErrors(t, diags)
+	if !gone || len(srv.named["groups"]) != 0 {
+		t.Fatalf("delete left state=%v server=%v", !gone, srv.named["groups"])
+	}
+
+	// deleted outside Terraform => read drops it
+	state, diags = r.read(state)
+	requireNoErrors(t, diags)
+	if !state.Raw.IsNull() {
+		t.Fatal("missing group kept in state")
+	}
+}
+
+func TestGroupResource_ValidateSelfReference(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		members tftypes.Value
+		wantErr bool
+	}{
+		{"bare name", "eng", tfStrings(setOf, "alice@example.com", "eng"), true},
+		{"group: reference", "eng", tfStrings(setOf, "group:eng"), true},
+		{"prefixed group name", "group:eng", tfStrings(setOf, "group:eng"), true},
+		{"other group", "eng", tfStrings(setOf, "group:ops", "engineering"), false},
+		{"null members", "eng", tftypes.NewValue(setOf(tftypes.String), nil), false},
+		{"unknown members", "eng", tftypes.NewValue(setOf(tftypes.String), tftypes.UnknownValue), false},
+	}
+	r := newTestResource(t, &taclProvider{}, NewGroupResource())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := r.validate(map[string]tftypes.Value{
+				"name":    tfString(tt.group),
+				"members": tt.members,
+			})
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("errors = %v, want error %v", diags.Errors(), tt.wantErr)
+			}
+			if tt.wantErr && diags.Errors()[0].Summary() != "Group references itself" {
+				t.Fatalf("summary = %q", diags.Errors()[0].Summary())
+			}
+		})
+	}
+}
+
+func TestGroupResource_MembersNullVersusEmpty(t *testing.T) {
+	tests := []struct {
+		name          string
+		members       tftypes.Value
+		wantSent      string // server members, "<unset>" when omitted from the body
+		wantStateNull bool
+		wantCount     int
+	}{
+		{"null", tftypes.NewValue(setOf(tftypes.String), nil), "<unset>", true, 0},
+		{"empty", tfStrings(setOf), "[]", false, 0},
+		{"set", tfStrings(setOf, "bob@example.com", "alice@example.com"), "[alice@example.com bob@example.com]", false, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFakeTACL(t)
+			r := newTestResource(t, newTestProvider(t, srv, nil), NewGroupResource())
+
+			state, diags := r.create(map[string]tftypes.Value{
+				"name":    tfString("eng"),
+				"members": tt.members,
+			})
+			requireNoErrors(t, diags)
+			sent, ok := srv.named["groups"]["eng"]["members"]
+			if got := fmt.Sprint(sent); !ok && tt.wantSent != "<unset>" || ok && got != tt.wantSent {
+				t.Fatalf("server members = %v (present %v), want %s", got, ok, tt.wantSent)
+			}
+
+			// TACL reports no members as [] either way; refresh must keep the configured form
+			srv.named["groups"]["eng"]["members"] = []interface{}{}
+			if tt.wantCount > 0 {
+				srv.named["groups"]["eng"]["members"] = []interface{}{"alice@example.com", "bob@example.com"}
+			}
+			state, diags = r.read(state)
+			requireNoErrors(t, diags)
+
+			var data groupResourceModel
+			requireNoErrors(t, state.Get(context.Background(), &data))
+			if got := data.Members == nil; got != tt.wantStateNull {
+				t.Fatalf("members null = %v, want %v (%v)", got, tt.wantStateNull, data.Members)
+			}
+			if len(data.Members) != tt.wantCount {
+				t.Fatalf("members = %v, want %d", data.Members, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestGroupResource_UpgradeStateV0(t *testing.T) {
+	testNameIDUpgrade(t, NewGroupResource, "groups", map[string]tftypes.Value{
+		"members": tfStrings(listOf, "alice@example.com"),
+	})
+}
+
+// TestGroupResource_UpgradeStateV0Members => v0 stored members as a list;
+// the upgraded set keeps its values, drops duplicates and keeps null / [].
+func TestGroupResource_UpgradeStateV0Members(t *testing.T) {
+	tests := []struct {
+		name     string
+		members  tftypes.Value
+		wantNull bool
+		want     []string
+	}{
+		{name: "list becomes a set", members: tfStrings(listOf, "bob@example.com", "alice@example.com"), want: []string{"alice@example.com", "bob@example.com"}},
+		{name: "duplicates dropped", members: tfStrings(listOf, "alice@example.com", "alice@example.com"), want: []string{"alice@example.com"}},
+		{name: "empty stays empty", members: tfStrings(listOf), want: []string{}},
+		{name: "null stays null", members: tftypes.NewValue(listOf(tftypes.String), nil), wantNull: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestResource(t, &taclProvider{}, NewGroupResource())
+			state, diags := r.upgradeState(0, map[string]tftypes.Value{
+				"id":      tfString("eng"),
+				"name":    tfString("eng"),
+				"members": tt.members,
+			})
+			requireNoErrors(t, diags)
+
+			var data groupResourceModel
+			requireNoErrors(t, state.Get(context.Background(), &data))
+			if got := data.Members == nil; got != tt.wantNull {
+				t.Fatalf("members null = %v, want %v (%v)", got, tt.wantNull, data.Members)
+			}
+			if !tt.wantNull && !reflect.DeepEqual(toStringSlice(data.Members), tt.want) {
+				t.Errorf("members = %v, want %v", data.Members, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccGroupResource(t *testing.T) {
+	srv := newFakeTACL(t)
+	config := func(members string) string {
+		return fmt.Sprintf(`
+provider "tacl" {
+  endpoint = %q
+}
+
+resource "tacl_group" "eng" {
+  name    = "eng"
+  members = %s
+}
+`, srv.URL, members)
+	}
+
+	tfresource.Test(t, tfresource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []tfresource.TestStep{
+			{
+				Config: config(`["alice@example.com"]`),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("tacl_group.eng", "id", "eng"),
+					tfresource.TestCheckResourceAttr("tacl_group.eng", "members.#", "1"),
+				),
+			},
+			{
+				Config: config(`["alice@example.com", "bob@example.com"]`),
+				Check:  tfresource.TestCheckResourceAttr("tacl_group.eng", "members.#", "2"),
+			},
+		},
+	})
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure DS compliance
+var (
+	_ datasource.DataSource              = &healthDataSource{}
+	_ datasource.DataSourceWithConfigure = &healthDataSource{}
+)
+
+// NewHealthDataSource => "tacl_health" data source
+func NewHealthDataSource() datasource.DataSource {
+	return &healthDataSource{}
+}
+
+type healthDataSource struct {
+	httpClient *http.Client
+	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
+}
+
+type healthDSModel struct {
+	ID      types.String `tfsdk:"id"`
+	Path    types.String `tfsdk:"path"`
+	Healthy types.Bool   `tfsdk:"healthy"`
+	Version types.String `tfsdk:"version"`
+}
+
+func (d *healthDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p, ok := req.ProviderData.(*taclProvider)
+	if !ok {
+		return
+	}
+	d.httpClient = p.httpClient
+	d.endpoint = p.endpoint
+	d.logMasks = p.logMasks
+}
+
+func (d *healthDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_health"
+}
+
+func (d *healthDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source that pings the TACL server. Never fails the plan: an unreachable server just reports healthy = false.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Always 'health'.",
+				Computed:    true,
+			},
+			"path": schema.StringAttribute{
+				Description: "Path to probe on the TACL server. Defaults to '/healthz'.",
+				Optional:    true,
+			},
+			"healthy": schema.BoolAttribute{
+				Description: "True if the server answered with a 2xx status.",
+				Computed:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "Server version, if the server reports one. Null otherwise.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *healthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = maskLogCtx(ctx, d.logMasks)
+	var data healthDSModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	path := "/healthz"
+	if !data.Path.IsNull() && data.Path.ValueString() != "" {
+		path = data.Path.ValueString()
+	}
+
+	// always relative to endpoint, even if the configured path lacks a leading "/"
+	getURL := fmt.Sprintf("%s/%s", d.endpoint, strings.TrimLeft(path, "/"))
+	tflog.Debug(ctx, "Probing TACL health", map[string]interface{}{"url": getURL})
+
+	healthy, version, err := doHealthRequest(ctx, d.httpClient, getURL)
+	if err != nil {
+		// Unreachable is a valid answer for this data source => warn only.
+		tflog.Warn(ctx, "TACL health probe failed", map[string]interface{}{
+			"url":   getURL,
+			"error": err.Error(),
+		})
+	}
+
+	data.ID = types.StringValue("health")
+	data.Healthy = types.BoolValue(healthy)
+	data.Version = stringOrNull(version)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// doHealthRequest => GET url, returns whether it answered 2xx plus the version
+// from either a JSON body { "version": "..." } or the X-Tacl-Version header.
+func doHealthRequest(ctx context.Context, client *http.Client, url string) (bool, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, "", fmt.Errorf("health request creation error: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, "", fmt.Errorf("health request error: %w", err)
+	}
+	defer res.Body.Close()
+
+	version := res.Header.Get("X-Tacl-Version")
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return false, version, fmt.Errorf("TACL returned %d", res.StatusCode)
+	}
+
+	body, _ := io.ReadAll(res.Body)
+	var parsed struct {
+		Version string `json:"version"`
+	}
+	if json.Unmarshal(body, &parsed) == nil && parsed.Version != "" {
+		version = parsed.Version
+	}
+	return true, version, nil
+}
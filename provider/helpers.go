@@ -3,14 +3,81 @@ package provider
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/oauth2"
 	"io"
+	"mime"
 	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
 )
 
+// sleepCtx => time.Sleep that returns ctx.Err() as soon as ctx is cancelled,
+// so retry/backoff waits don't hold up an interrupted terraform run.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// readAfterWrite => provider's read_after_write_attempts/_interval: how many
+// times Create GETs a just-created object, and how long it waits between tries,
+// before trusting it's visible. attempts == 0 => no confirmation read.
+type readAfterWrite struct {
+	attempts int
+	interval time.Duration
+}
+
+// awaitCreated => call get until it stops returning NotFound or rw.attempts
+// run out. Returns (nil, nil) when polling is off. Replication lag on some
+// TACL deployments makes a GET right after POST briefly 404.
+func awaitCreated(ctx context.Context, rw readAfterWrite, get func() ([]byte, error)) ([]byte, error) {
+	if rw.attempts <= 0 {
+		return nil, nil
+	}
+	for attempt := 1; ; attempt++ {
+		body, err := get()
+		if err == nil || !isNotFound(err) || attempt >= rw.attempts {
+			return body, err
+		}
+		tflog.Debug(ctx, "Created object not readable yet, polling again", map[string]interface{}{
+			"attempt":     attempt,
+			"interval_ms": rw.interval.Milliseconds(),
+		})
+		if err := sleepCtx(ctx, rw.interval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// addReadAfterWriteWarning => the object was created but the confirmation
+// read failed; state comes from the create response instead.
+func addReadAfterWriteWarning(diags *diag.Diagnostics, kind string, err error) {
+	diags.AddWarning(fmt.Sprintf("%s not readable after create", kind),
+		fmt.Sprintf("The %s was created, but reading it back failed: %s. State was filled from the create response; "+
+			"consider raising read_after_write_attempts.", kind, err))
+}
+
 // Equality helper
 func equalStringSlice(a, b []string) bool {
 	if len(a) != len(b) {
@@ -33,6 +100,52 @@ func toGoStringSlice(tf []types.String) []string {
 	return out
 }
 
+// normalizeStrings => trim whitespace and drop repeats, keeping first-seen order
+func normalizeStrings(in []string) []string {
+	out := make([]string, 0, len(in))
+	seen := make(map[string]bool, len(in))
+	for _, v := range in {
+		v = strings.TrimSpace(v)
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	return out
+}
+
+// listPayload => list attribute as sent to TACL, normalized if the provider's
+// normalize_lists is on
+func listPayload(l []types.String, normalize bool) []string {
+	out := toStringSlice(l)
+	if normalize {
+		out = normalizeStrings(out)
+	}
+	return out
+}
+
+// normalizedOrPrior => the server's list, unless prior normalizes to exactly
+// that list. Terraform requires state to match config for non-computed
+// attributes, so the configured spelling is kept when the only difference is
+// what normalize_lists removed.
+func normalizedOrPrior(server []string, prior []types.String) []types.String {
+	if prior != nil && equalStringSlice(normalizeStrings(toStringSlice(prior)), server) {
+		return prior
+	}
+	return toTerraformStringSlice(server)
+}
+
+// optionalListOrPrior => like normalizedOrPrior for optional lists the server
+// omits when empty: an omitted list keeps the prior null/[] instead of
+// flipping between the two.
+func optionalListOrPrior(server []string, prior []types.String) []types.String {
+	if len(server) == 0 && len(prior) == 0 {
+		return prior
+	}
+	return normalizedOrPrior(server, prior)
+}
+
 // Another alias: toStringSlice => same logic
 func toStringSlice(arr []types.String) []string {
 	out := make([]string, len(arr))
@@ -42,16 +155,28 @@ func toStringSlice(arr []types.String) []string {
 	return out
 }
 
-// Convert []interface{} => []types.String
-func toStringTypeSlice(arr []interface{}) []types.String {
-	out := make([]types.String, len(arr))
-	for i, v := range arr {
+// uniqueSortedStrings => sorted copy without duplicates, the canonical form
+// of a string set (e.g. for payloads, so they don't depend on config order)
+func uniqueSortedStrings(in []string) []string {
+	out := sortedStrings(in)
+	return slices.Compact(out)
+}
+
+// toStringSetElements => JSON strings as the elements of a string set
+// attribute: sorted and deduplicated (a set rejects duplicates), skipping
+// non-strings. Resources and data sources share it so their output agrees.
+func toStringSetElements(arr []interface{}) []types.String {
+	var values []string
+	for _, v := range arr {
 		if s, ok := v.(string); ok {
-			out[i] = types.StringValue(s)
-		} else {
-			out[i] = types.StringNull()
+			values = append(values, s)
 		}
 	}
+	values = uniqueSortedStrings(values)
+	out := make([]types.String, len(values))
+	for i, v := range values {
+		out[i] = types.StringValue(v)
+	}
 	return out
 }
 
@@ -63,9 +188,280 @@ type NotFoundError struct {
 func (e *NotFoundError) Error() string {
 	return e.Message
 }
-func IsNotFound(err error) bool {
-	_, ok := err.(*NotFoundError)
-	return ok
+
+// isNotFound => the one not-found check: a NotFoundError from any doXxxRequest
+// helper, or an APIError carrying 404, however deeply wrapped
+func isNotFound(err error) bool {
+	var nf *NotFoundError
+	if errors.As(err, &nf) {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// PreconditionFailedError => TACL answered 412 to an If-Match request,
+// i.e. the object changed since we last read it.
+type PreconditionFailedError struct {
+	Message string
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return e.Message
+}
+
+func isPreconditionFailed(err error) bool {
+	var pf *PreconditionFailedError
+	return errors.As(err, &pf)
+}
+
+// isConflict => TACL answered 409, e.g. the object is still referenced
+func isConflict(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
+
+// APIError => TACL answered with a non-2xx status (other than 404), or with
+// a 2xx error body when detect_error_bodies is set.
+// Message/Field are filled in when the body is a JSON error object such as
+// { "error": "...", "field": "src" }.
+type APIError struct {
+	StatusCode int
+	Method     string // request that failed, e.g. "GET"
+	URL        string
+	Body       []byte
+	Message    string
+	Field      string
+	// ContentType is the response's Content-Type. A non-JSON one (usually
+	// text/html) means something in front of TACL answered instead.
+	ContentType string
+}
+
+func (e *APIError) Error() string {
+	if e.URL != "" {
+		return fmt.Sprintf("TACL returned %d for %s %s%s: %s", e.StatusCode, e.Method, e.URL, e.contentTypeNote(), prettyJSON(e.Body))
+	}
+	return fmt.Sprintf("TACL returned %d%s: %s", e.StatusCode, e.contentTypeNote(), prettyJSON(e.Body))
+}
+
+// contentTypeNote => " (Content-Type text/html, ...)" when the error body
+// isn't JSON, else ""
+func (e *APIError) contentTypeNote() string {
+	if e.ContentType == "" || isJSONContentType(e.ContentType) {
+		return ""
+	}
+	return fmt.Sprintf(" (Content-Type %s, not JSON: likely a proxy or load balancer in front of TACL)", e.ContentType)
+}
+
+// isJSONContentType => application/json, application/problem+json, etc.
+func isJSONContentType(ct string) bool {
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// AuthError => TACL answered 401/403. Wraps the *APIError so callers that
+// only care about the status/body still find it with errors.As.
+type AuthError struct {
+	APIError *APIError
+}
+
+func (e *AuthError) Error() string {
+	return "authentication failed: " + e.APIError.Error()
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.APIError
+}
+
+// newAPIError => build an *APIError (or *AuthError for 401/403) from a non-2xx
+// response, parsing the body if it's JSON
+func newAPIError(res *http.Response, body []byte) error {
+	status := res.StatusCode
+	apiErr := &APIError{StatusCode: status, Body: body, ContentType: res.Header.Get("Content-Type")}
+	if res.Request != nil && res.Request.URL != nil {
+		apiErr.Method = res.Request.Method
+		apiErr.URL = res.Request.URL.String()
+	}
+
+	var parsed map[string]interface{}
+	if json.Unmarshal(body, &parsed) == nil {
+		for _, key := range []string{"error", "message", "detail"} {
+			if msg, ok := parsed[key].(string); ok && msg != "" {
+				apiErr.Message = msg
+				break
+			}
+		}
+		for _, key := range []string{"field", "attribute", "path"} {
+			if field, ok := parsed[key].(string); ok && field != "" {
+				apiErr.Field = field
+				break
+			}
+		}
+	}
+	if status == http.StatusUnauthorized || status == http.StatusForbidden {
+		return &AuthError{APIError: apiErr}
+	}
+	return apiErr
+}
+
+// addDSNotFoundError => the one way data sources report a 404: looking
+// something up that doesn't exist is an error, naming what was looked up.
+// lookup is e.g. `named "dev"`; empty for singletons like settings.
+func addDSNotFoundError(diags *diag.Diagnostics, kind, lookup string) {
+	detail := fmt.Sprintf("TACL has no %s configured.", kind)
+	if lookup != "" {
+		detail = fmt.Sprintf("TACL has no %s %s.", kind, lookup)
+	}
+	diags.AddError(kind+" not found", detail)
+}
+
+// addDSMissingDiagnostic => for lookups with `fail_if_missing`: an error if
+// it's true, otherwise a warning and the data source's attributes stay null.
+func addDSMissingDiagnostic(diags *diag.Diagnostics, failIfMissing types.Bool, kind, lookup string) {
+	if failIfMissing.ValueBool() {
+		addDSNotFoundError(diags, kind, lookup)
+		return
+	}
+	what := kind + " configured"
+	if lookup != "" {
+		what = kind + " " + lookup
+	}
+	detail := fmt.Sprintf("TACL has no %s. Its attributes are left null; set fail_if_missing = true to make this an error.", what)
+	diags.AddWarning(kind+" not found", detail)
+}
+
+// addAuthErrorDiagnostic => if err is a 401/403 from TACL, or the OAuth token
+// exchange itself failed, add a diagnostic pointing at the provider config and
+// return true.
+func addAuthErrorDiagnostic(diags *diag.Diagnostics, err error) bool {
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		status := authErr.APIError.StatusCode
+		if status == http.StatusForbidden {
+			diags.AddError("Authorization failed",
+				fmt.Sprintf("TACL returned HTTP %d: the configured credentials were accepted but aren't allowed to do this. "+
+					"Check the scopes/tags granted to client_id in the provider configuration.\n\nResponse body:\n%s",
+					status, prettyJSON(authErr.APIError.Body)))
+			return true
+		}
+		diags.AddError("Authentication failed",
+			fmt.Sprintf("TACL returned HTTP %d. Check client_id/client_secret in the provider configuration; "+
+				"the OAuth client may be wrong, revoked or expired.\n\nResponse body:\n%s",
+				status, prettyJSON(authErr.APIError.Body)))
+		return true
+	}
+
+	var tokenErr *oauth2.RetrieveError
+	if errors.As(err, &tokenErr) {
+		diags.AddError("Authentication failed",
+			fmt.Sprintf("Could not obtain an OAuth token. Check client_id/client_secret in the provider configuration.\n\n%s",
+				tokenErr.Error()))
+		return true
+	}
+	return false
+}
+
+// prettyJSON => indent body if it's JSON, otherwise return it as-is
+func prettyJSON(body []byte) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, body, "", "  "); err != nil {
+		return strings.TrimSpace(string(body))
+	}
+	return out.String()
+}
+
+// addAPIErrorDiagnostic => AddError, but for *APIError we split the server
+// message into the summary, pretty-print the body into the detail, and point
+// at the offending attribute if TACL named one.
+func addAPIErrorDiagnostic(diags *diag.Diagnostics, summary string, err error) {
+	if addAuthErrorDiagnostic(diags, err) {
+		return
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		diags.AddError(summary, err.Error())
+		return
+	}
+
+	if apiErr.Message != "" {
+		summary = fmt.Sprintf("%s: %s", summary, apiErr.Message)
+	}
+	detail := fmt.Sprintf("TACL returned HTTP %d%s.\n\nResponse body:\n%s", apiErr.StatusCode, apiErr.contentTypeNote(), prettyJSON(apiErr.Body))
+	if apiErr.URL != "" {
+		detail = fmt.Sprintf("TACL returned HTTP %d for %s %s%s.\n\nResponse body:\n%s",
+			apiErr.StatusCode, apiErr.Method, apiErr.URL, apiErr.contentTypeNote(), prettyJSON(apiErr.Body))
+	}
+	if err.Error() != apiErr.Error() {
+		// keep any context the caller wrapped around it (e.g. which tag)
+		detail = err.Error() + "\n\n" + detail
+	}
+
+	if apiErr.Field != "" {
+		diags.AddAttributeError(path.Root(toSnakeCase(apiErr.Field)), summary, detail)
+		return
+	}
+	diags.AddError(summary, detail)
+}
+
+// snakeCaseOverrides => TACL JSON names the generic rule gets wrong
+var snakeCaseOverrides = map[string]string{
+	"disableIPv4": "disable_ipv4",
+	"IPv4":        "ipv4",
+	"IPv6":        "ipv6",
+}
+
+// toSnakeCase => "checkPeriod" => "check_period", "oneCGNATRoute" =>
+// "one_cgnat_route", to map TACL JSON field names onto our attribute names.
+func toSnakeCase(s string) string {
+	if o, ok := snakeCaseOverrides[s]; ok {
+		return o
+	}
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// idempotencyKeyCtxKey => context key carrying the Idempotency-Key for a create
+type idempotencyKeyCtxKey struct{}
+
+// withIdempotencyKey => attach a fresh Idempotency-Key to ctx. Every request
+// made with the returned ctx (including retries) sends the same key, so a
+// server that supports it can dedupe a POST that timed out mid-flight.
+func withIdempotencyKey(ctx context.Context) (context.Context, string) {
+	key := newUUID()
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key), key
+}
+
+// setIdempotencyKey => copy the key from ctx (if any) onto req
+func setIdempotencyKey(ctx context.Context, req *http.Request) {
+	if key, ok := ctx.Value(idempotencyKeyCtxKey{}).(string); ok && key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+}
+
+// newUUID => random (v4) UUID string
+func newUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("crypto/rand failed: %s", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
 }
 
 // doSingleObjectReq => JSON request for single-object endpoints
@@ -96,7 +492,7 @@ func doSingleObjectReq(ctx context.Context, client *http.Client, method, url str
 	}
 	if res.StatusCode >= 300 {
 		msg, _ := io.ReadAll(res.Body)
-		return nil, fmt.Errorf("TACL returned %d: %s", res.StatusCode, msg)
+		return nil, newAPIError(res, msg)
 	}
 
 	return io.ReadAll(res.Body)
@@ -171,11 +567,6 @@ func toTerraformStringSlice(ss []string) []types.String {
 	return out
 }
 
-func isNotFound(err error) bool {
-	_, ok := err.(*NotFoundError)
-	return ok
-}
-
 // listToStringSlice => read a types.List of strings into a Go []string
 func listToStringSlice(ctx context.Context, l types.List) ([]string, error) {
 	if l.IsNull() || l.IsUnknown() {
@@ -216,6 +607,19 @@ func toStringListValue(ctx context.Context, arr []string) (types.List, error) {
 	return val, nil
 }
 
+// listFullyKnown => neither the list nor any element is unknown
+func listFullyKnown(l types.List) bool {
+	if l.IsUnknown() {
+		return false
+	}
+	for _, e := range l.Elements() {
+		if e.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
 // Convert types.List => []string
 func listToGoStrings(ctx context.Context, l types.List) ([]string, error) {
 	if l.IsNull() || l.IsUnknown() {
@@ -247,7 +651,314 @@ func goStringsToList(arr []string) (types.List, error) {
 	return listVal, nil
 }
 
+// stringOrNull => "" becomes null, for Optional attributes the server
+// returns as empty strings when unset
+func stringOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
+
+// protoOrPrior => the server's proto, unless prior names the same protocol
+// (TACL may turn "6" into "tcp" or the reverse)
+func protoOrPrior(server string, prior types.String) types.String {
+	if server != "" && !prior.IsNull() && !prior.IsUnknown() && protosEqual(server, prior.ValueString()) {
+		return prior
+	}
+	return stringOrNull(server)
+}
+
+// commentOrPrior => the server's comment if it sent one, otherwise the
+// configured/prior value, so servers that drop unknown fields don't cause drift.
+func commentOrPrior(server string, prior types.String) types.String {
+	if server != "" {
+		return types.StringValue(server)
+	}
+	return prior
+}
+
 // Return a nil slice so the final state sees it as null
 func nilListOfString() []types.String {
 	return nil
 }
+
+// sensitiveLogKeys => JSON keys whose values must never reach tflog output.
+// Matching is case-insensitive.
+var sensitiveLogKeys = map[string]struct{}{
+	"client_secret": {},
+	"clientsecret":  {},
+	"secret":        {},
+	"password":      {},
+	"token":         {},
+	"access_token":  {},
+	"accesstoken":   {},
+	"authkey":       {},
+	"auth_key":      {},
+	"apikey":        {},
+	"api_key":       {},
+	"authorization": {},
+}
+
+const redactedValue = "***REDACTED***"
+
+// maskLogCtx => ctx with tflog masking for the provider's secrets: fields
+// named in sensitiveLogKeys, plus every masks value (client_secret, header
+// values) wherever it shows up in a field or message. Masks set in Configure
+// only cover the Configure ctx, so every CRUD method wraps its own ctx.
+func maskLogCtx(ctx context.Context, masks []string) context.Context {
+	keys := make([]string, 0, len(sensitiveLogKeys))
+	for k := range sensitiveLogKeys {
+		keys = append(keys, k)
+	}
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, keys...)
+	if len(masks) > 0 {
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, masks...)
+		ctx = tflog.MaskMessageStrings(ctx, masks...)
+	}
+	return ctx
+}
+
+// redactForLog => round-trips a payload through JSON and masks the values of
+// any sensitiveLogKeys, at any depth. Use it for every payload passed to tflog.
+// If the payload can't be marshaled we log a placeholder rather than risk it.
+func redactForLog(payload interface{}) interface{} {
+	if payload == nil {
+		return nil
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "<unloggable payload>"
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return "<unloggable payload>"
+	}
+	return redactValue(generic)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, inner := range val {
+			if _, ok := sensitiveLogKeys[strings.ToLower(k)]; ok {
+				val[k] = redactedValue
+				continue
+			}
+			val[k] = redactValue(inner)
+		}
+		return val
+	case []interface{}:
+		for i, inner := range val {
+			val[i] = redactValue(inner)
+		}
+		return val
+	default:
+		return val
+	}
+}
+
+// rawJSONAttribute => computed `raw_json` for resources that can keep the
+// server's last response (provider debug = true). UseStateForUnknown keeps it
+// out of plans; it's refreshed on create and read, not on update.
+func rawJSONAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "Last response body TACL returned for this resource, when the provider's `debug` is true. " +
+			"Set on create and refreshed on read. Null otherwise.",
+		Computed: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+// auditFields => who last changed an object and when, embedded in response
+// types. Only some TACL versions record these; older ones send neither.
+type auditFields struct {
+	UpdatedBy string `json:"updatedBy,omitempty"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// auditValues => last_modified_by / last_modified_at, null when not sent
+func (a auditFields) auditValues() (by, at types.String) {
+	return stringOrNull(a.UpdatedBy), stringOrNull(a.UpdatedAt)
+}
+
+// lastModifiedByAttribute / lastModifiedAtAttribute => computed audit
+// attributes. No plan modifier: any update changes them.
+func lastModifiedByAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "Who last changed this object, as recorded by TACL (updatedBy). Null if the server doesn't track it.",
+		Computed:    true,
+	}
+}
+
+func lastModifiedAtAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "When this object was last changed, as recorded by TACL (updatedAt). Null if the server doesn't track it.",
+		Computed:    true,
+	}
+}
+
+// rawJSONValue => body as raw_json, or null when debug is off
+func rawJSONValue(debug bool, body []byte) types.String {
+	if !debug || body == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(string(body))
+}
+
+// rawJSONOnUpdate => the planned raw_json (prior state, via
+// UseStateForUnknown) so apply matches plan; only filled from body when the
+// plan left it unknown, i.e. there was no prior value.
+func rawJSONOnUpdate(planned types.String, debug bool, body []byte) types.String {
+	if planned.IsUnknown() {
+		return rawJSONValue(debug, body)
+	}
+	return planned
+}
+
+// serverObjectID => the "id" TACL reports for a name-addressed object
+// (group, host, tagowner), for server_id. Null for servers that key them by
+// name only. Their Terraform id stays the name, so `group:${...id}` style
+// references keep working.
+func serverObjectID(body []byte) types.String {
+	var obj struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(body, &obj) != nil {
+		return types.StringNull()
+	}
+	return stringOrNull(obj.ID)
+}
+
+// upgradeServerID => for v0 state of name-addressed resources: re-read the
+// object by name and take the server's current ID for server_id. Best effort:
+// if the provider isn't configured yet or the read fails, it's left null and
+// the next refresh fills it in.
+func upgradeServerID(ctx context.Context, client *http.Client, getURL, name string) types.String {
+	if client == nil || name == "" {
+		return types.StringNull()
+	}
+	body, err := doSingleObjectReq(ctx, client, http.MethodGet, getURL, nil)
+	if err != nil {
+		tflog.Debug(ctx, "Could not re-read object during state upgrade, leaving server_id unset", map[string]interface{}{
+			"url":   getURL,
+			"error": err.Error(),
+		})
+		return types.StringNull()
+	}
+	return serverObjectID(body)
+}
+
+// nameIDPlanModifier => UseStateForUnknown for the id or server_id of a
+// name-addressed resource, except when the name changes: the object is
+// re-keyed then, so it has to stay unknown.
+type nameIDPlanModifier struct{}
+
+var _ planmodifier.String = nameIDPlanModifier{}
+
+func (m nameIDPlanModifier) Description(ctx context.Context) string {
+	return "Keeps the prior id unless name changes."
+}
+
+func (m nameIDPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return "Keeps the prior id unless `name` changes."
+}
+
+func (m nameIDPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	var planName, stateName types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &planName)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &stateName)...)
+	if resp.Diagnostics.HasError() || !planName.Equal(stateName) {
+		return
+	}
+	resp.PlanValue = req.StateValue
+}
+
+// deletionProtectionAttribute => `deletion_protection` for tailnet-wide
+// singletons, where a stray destroy wipes global state. Client-side only.
+func deletionProtectionAttribute(defaultOn bool) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: fmt.Sprintf("If true, destroying this resource fails instead of deleting it in TACL. "+
+			"Set it to false and apply before destroying (or `terraform state rm` to stop managing it). Defaults to %t.", defaultOn),
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(defaultOn),
+	}
+}
+
+// deletionProtectionOrDefault => the state's value; null (state written
+// before the attribute existed, or after import) counts as the default
+func deletionProtectionOrDefault(v types.Bool, defaultOn bool) types.Bool {
+	if v.IsNull() || v.IsUnknown() {
+		return types.BoolValue(defaultOn)
+	}
+	return v
+}
+
+// checkDeletionProtection => adds the error and returns false if Delete must
+// not proceed
+func checkDeletionProtection(diags *diag.Diagnostics, protected types.Bool, defaultOn bool, kind string) bool {
+	if !deletionProtectionOrDefault(protected, defaultOn).ValueBool() {
+		return true
+	}
+	diags.AddError("Deletion protection enabled",
+		fmt.Sprintf("Refusing to delete the tailnet-wide %s: deletion_protection is true. Set deletion_protection = false "+
+			"and apply first, or use `terraform state rm` to stop managing it without deleting it.", kind))
+	return false
+}
+
+// jsonFieldNames => the JSON names of v's struct fields, omitempty or not,
+// i.e. every top-level field the provider models for that object
+func jsonFieldNames(v interface{}) map[string]bool {
+	names := map[string]bool{}
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" && f.Anonymous && f.Type.Kind() == reflect.Struct {
+			// embedded struct => its fields are promoted into the object
+			for n := range jsonFieldNames(reflect.Zero(f.Type).Interface()) {
+				names[n] = true
+			}
+			continue
+		}
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// withUnknownFields => body as a map, plus every top-level field of current
+// (the server's copy of the object) the provider doesn't model, so a PUT
+// rebuilt from state doesn't strip fields added by newer TACL versions.
+// Modeled fields are never copied: leaving one out of body (omitempty) must
+// still clear it. "id" is addressed separately and never copied either.
+func withUnknownFields(current []byte, body interface{}, modeled map[string]bool) (map[string]interface{}, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, err
+	}
+	var server map[string]interface{}
+	if err := json.Unmarshal(current, &server); err != nil {
+		return nil, fmt.Errorf("parse current object: %w", err)
+	}
+	for k, v := range server {
+		if k == "id" || modeled[k] {
+			continue
+		}
+		if _, ok := out[k]; !ok {
+			out[k] = v
+		}
+	}
+	return out, nil
+}
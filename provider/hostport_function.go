@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure interface compliance
+var _ function.Function = &hostportFunction{}
+
+// NewHostPortFunction => provider::tacl::hostport(host, port)
+func NewHostPortFunction() function.Function {
+	return &hostportFunction{}
+}
+
+// hostportFunction => builds "<host>:<port>" for ACL dst entries
+type hostportFunction struct{}
+
+func (f *hostportFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "hostport"
+}
+
+func (f *hostportFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build an ACL destination host:port string",
+		Description: "Returns \"<host>:<port>\", e.g. \"10.1.2.3/32:22\" or \"tag:prod:443\". " +
+			"Bare IPv6 addresses are wrapped in brackets.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "host",
+				Description: "Host, CIDR, tag, or group, e.g. '10.1.2.3/32'.",
+			},
+			function.Int64Parameter{
+				Name:        "port",
+				Description: "Port number, 1-65535.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *hostportFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var host string
+	var port int64
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &host, &port))
+	if resp.Error != nil {
+		return
+	}
+
+	host = strings.TrimSpace(host)
+	if host == "" {
+		resp.Error = function.NewArgumentFuncError(0, "host must not be empty")
+		return
+	}
+	if port < 1 || port > 65535 {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("port %d is out of range (1-65535)", port))
+		return
+	}
+
+	// "fd7a::1" => "[fd7a::1]"; tags/groups/IPv4 keep their colons as-is
+	if strings.Count(host, ":") > 1 && !strings.HasPrefix(host, "[") &&
+		!strings.HasPrefix(host, "tag:") && !strings.HasPrefix(host, "group:") && !strings.HasPrefix(host, "autogroup:") {
+		host = "[" + host + "]"
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, fmt.Sprintf("%s:%d", host, port)))
+}
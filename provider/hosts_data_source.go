@@ -27,12 +27,15 @@ func NewHostsDataSource() datasource.DataSource {
 type hostsDataSource struct {
 	httpClient *http.Client
 	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
 }
 
 type hostsDataSourceModel struct {
 	ID   types.String `tfsdk:"id"`
 	Name types.String `tfsdk:"name"`
 	IP   types.String `tfsdk:"ip"`
+
+	FailIfMissing types.Bool `tfsdk:"fail_if_missing"`
 }
 
 func (d *hostsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -43,8 +46,9 @@ func (d *hostsDataSource) Configure(ctx context.Context, req datasource.Configur
 	if !ok {
 		return
 	}
-	d.httpClient = p.httpClient
+	d.httpClient = p.dsHTTPClient
 	d.endpoint = p.endpoint
+	d.logMasks = p.logMasks
 }
 
 func (d *hostsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -57,6 +61,10 @@ func (d *hostsDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 	resp.Schema = schema.Schema{
 		Description: "Data source for reading one host by name from /hosts.",
 		Attributes: map[string]schema.Attribute{
+			"fail_if_missing": schema.BoolAttribute{
+				Description: "If true, a lookup that finds nothing is an error. Defaults to false: a warning, with attributes left null.",
+				Optional:    true,
+			},
 			"id": schema.StringAttribute{
 				Description: "Same as 'name' after read.",
 				Computed:    true,
@@ -75,6 +83,7 @@ func (d *hostsDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 
 // Read => GET /hosts/:name
 func (d *hostsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = maskLogCtx(ctx, d.logMasks)
 	var data hostsDataSourceModel
 
 	diags := req.Config.Get(ctx, &data)
@@ -92,11 +101,11 @@ func (d *hostsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	body, err := doHostsDSRequest(ctx, d.httpClient, http.MethodGet, getURL, nil)
 	if err != nil {
-		if IsNotFound(err) {
-			resp.Diagnostics.AddWarning("Host not found", fmt.Sprintf("No host named '%s' found", name))
+		if isNotFound(err) {
+			addDSMissingDiagnostic(&resp.Diagnostics, data.FailIfMissing, "Host", fmt.Sprintf("named %q", name))
 			return
 		}
-		resp.Diagnostics.AddError("Read host DS error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read host DS error", err)
 		return
 	}
 
@@ -145,7 +154,7 @@ func doHostsDSRequest(ctx context.Context, client *http.Client, method, url stri
 	}
 	if resp.StatusCode >= 300 {
 		msg, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("TACL returned HTTP %d: %s", resp.StatusCode, msg)
+		return nil, newAPIError(resp, msg)
 	}
 
 	return io.ReadAll(resp.Body)
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// hostsMapDataSource => every host in /hosts as one name => ip map
+var (
+	_ datasource.DataSource              = &hostsMapDataSource{}
+	_ datasource.DataSourceWithConfigure = &hostsMapDataSource{}
+)
+
+func NewHostsMapDataSource() datasource.DataSource {
+	return &hostsMapDataSource{}
+}
+
+type hostsMapDataSource struct {
+	httpClient *http.Client
+	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
+}
+
+type hostsMapDSModel struct {
+	ID    types.String `tfsdk:"id"`
+	Hosts types.Map    `tfsdk:"hosts"`
+}
+
+// hostsListEntry => one element of GET /hosts
+type hostsListEntry struct {
+	Name string `json:"name"`
+	IP   string `json:"ip"`
+}
+
+func (d *hostsMapDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p, ok := req.ProviderData.(*taclProvider)
+	if !ok {
+		return
+	}
+	d.httpClient = p.dsHTTPClient
+	d.endpoint = p.endpoint
+	d.logMasks = p.logMasks
+}
+
+func (d *hostsMapDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hosts_map"
+}
+
+func (d *hostsMapDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source returning every host in /hosts as a single name => IP map, e.g. for templating DNS records.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Always 'hosts'.",
+				Computed:    true,
+			},
+			"hosts": schema.MapAttribute{
+				Description: "Host name => IP address. Empty (not null) when TACL has no hosts.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Read => GET /hosts (following pagination)
+func (d *hostsMapDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = maskLogCtx(ctx, d.logMasks)
+	var data hostsMapDSModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listURL := fmt.Sprintf("%s/hosts", d.endpoint)
+	tflog.Debug(ctx, "Listing hosts (map data source)", map[string]interface{}{
+		"url": listURL,
+	})
+
+	items, err := doListRequest(ctx, d.httpClient, listURL)
+	if err != nil && !isNotFound(err) {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "List hosts DS error", err)
+		return
+	}
+
+	hosts := make(map[string]string, len(items))
+	for _, raw := range items {
+		var h hostsListEntry
+		if err := json.Unmarshal(raw, &h); err != nil {
+			resp.Diagnostics.AddError("Parse DS response error", err.Error())
+			return
+		}
+		hosts[h.Name] = h.IP
+	}
+
+	m, mapDiags := types.MapValueFrom(ctx, types.StringType, hosts)
+	resp.Diagnostics.Append(mapDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue("hosts")
+	data.Hosts = m
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
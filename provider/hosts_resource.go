@@ -10,14 +10,17 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // hostsResource implements Resource and ResourceWithConfigure for "tacl_hosts" (multi-object).
 var (
-	_ resource.Resource              = &hostsResource{}
-	_ resource.ResourceWithConfigure = &hostsResource{}
+	_ resource.Resource                 = &hostsResource{}
+	_ resource.ResourceWithConfigure    = &hostsResource{}
+	_ resource.ResourceWithUpgradeState = &hostsResource{}
 )
 
 // NewHostsResource is the constructor for "tacl_host" resource
@@ -30,13 +33,17 @@ func NewHostsResource() resource.Resource {
 type hostsResource struct {
 	httpClient *http.Client
 	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
 }
 
 // hostsResourceModel => "tacl_host"
 type hostsResourceModel struct {
-	ID   types.String `tfsdk:"id"`   // we store the host's Name as ID
-	Name types.String `tfsdk:"name"` // required
-	IP   types.String `tfsdk:"ip"`   // required
+	ID       types.String `tfsdk:"id"`        // we store the host's Name as ID
+	ServerID types.String `tfsdk:"server_id"` // the server's own ID, if it reports one
+	Name     types.String `tfsdk:"name"`      // required
+	IP       types.String `tfsdk:"ip"`        // required
+
+	StrictDelete types.Bool `tfsdk:"strict_delete"` // only delete if the server still has our IP
 }
 
 // Configure => retrieve the provider’s HTTP client & endpoint
@@ -50,6 +57,7 @@ func (r *hostsResource) Configure(ctx context.Context, req resource.ConfigureReq
 	}
 	r.httpClient = p.httpClient
 	r.endpoint = p.endpoint
+	r.logMasks = p.logMasks
 }
 
 // Metadata => resource type "tacl_host"
@@ -61,10 +69,22 @@ func (r *hostsResource) Metadata(ctx context.Context, req resource.MetadataReque
 func (r *hostsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a single host entry in TACL’s /hosts array, which is ultimately stored as a map of Name=>IP.",
+		// v1 => server_id holds the server's ID; id stays the name
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Same as the host's Name.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					nameIDPlanModifier{},
+				},
+			},
+			"server_id": schema.StringAttribute{
+				Description: "ID TACL reports for the host, e.g. a UUID on newer servers. Null for servers that key hosts by name only.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					nameIDPlanModifier{},
+				},
 			},
 			"name": schema.StringAttribute{
 				Description: "Unique hostname.",
@@ -74,12 +94,68 @@ func (r *hostsResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Description: "IP address (or IP/CIDR) for this host.",
 				Required:    true,
 			},
+			"strict_delete": schema.BoolAttribute{
+				Description: "If true, destroy first reads the host and skips the DELETE (with a warning) when its IP " +
+					"no longer matches state, e.g. because someone re-pointed it outside Terraform. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// hostsResourceModelV0 => tacl_host state as written by schema version 0
+type hostsResourceModelV0 struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	IP           types.String `tfsdk:"ip"`
+	StrictDelete types.Bool   `tfsdk:"strict_delete"`
+}
+
+// hostsResourceSchemaV0 => tacl_host schema version 0, frozen; don't edit it along
+// with Schema.
+func hostsResourceSchemaV0() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":            schema.StringAttribute{Computed: true},
+			"name":          schema.StringAttribute{Required: true},
+			"ip":            schema.StringAttribute{Required: true},
+			"strict_delete": schema.BoolAttribute{Optional: true, Computed: true},
+		},
+	}
+}
+
+// UpgradeState => v0 had no server_id. Re-read by name so state picks up the
+// server's ID (a UUID on newer TACL); id stays the name.
+func (r *hostsResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	priorSchema := hostsResourceSchemaV0()
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior hostsResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				name := prior.Name.ValueString()
+				data := hostsResourceModel{
+					ID:           types.StringValue(name),
+					ServerID:     upgradeServerID(ctx, r.httpClient, fmt.Sprintf("%s/hosts/%s", r.endpoint, name), name),
+					Name:         prior.Name,
+					IP:           prior.IP,
+					StrictDelete: prior.StrictDelete,
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
 		},
 	}
 }
 
 // Create => POST /hosts => add new host
 func (r *hostsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data hostsResourceModel
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -95,12 +171,12 @@ func (r *hostsResource) Create(ctx context.Context, req resource.CreateRequest,
 	postURL := fmt.Sprintf("%s/hosts", r.endpoint)
 	tflog.Debug(ctx, "Creating host via TACL", map[string]interface{}{
 		"url":     postURL,
-		"payload": payload,
+		"payload": redactForLog(payload),
 	})
 
 	body, err := doHostsRequest(ctx, r.httpClient, http.MethodPost, postURL, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Create host error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Create host error", err)
 		return
 	}
 
@@ -111,8 +187,8 @@ func (r *hostsResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	// We'll store ID = Name
 	data.ID = data.Name
+	data.ServerID = serverObjectID(body)
 
 	// Save final state
 	diags = resp.State.Set(ctx, &data)
@@ -121,6 +197,7 @@ func (r *hostsResource) Create(ctx context.Context, req resource.CreateRequest,
 
 // Read => GET /hosts/:name => retrieve a single host
 func (r *hostsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data hostsResourceModel
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -137,13 +214,13 @@ func (r *hostsResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	body, err := doHostsRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
 	if err != nil {
-		if IsNotFound(err) {
+		if isNotFound(err) {
 			// Host not found => remove from state
 			tflog.Warn(ctx, "Host not found, removing from state", map[string]interface{}{"name": name})
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Read host error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read host error", err)
 		return
 	}
 
@@ -153,11 +230,16 @@ func (r *hostsResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	data.ID = data.Name
+	data.ID = types.StringValue(name)
+	data.ServerID = serverObjectID(body)
 	// We expect { "name":"...", "ip":"..." }
 	if ip, ok := fetched["ip"].(string); ok {
 		data.IP = types.StringValue(ip)
 	}
+	if data.StrictDelete.IsNull() {
+		// imported or created before strict_delete existed
+		data.StrictDelete = types.BoolValue(false)
+	}
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -165,6 +247,7 @@ func (r *hostsResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // Update => PUT /hosts => { "name":..., "ip":... }
 func (r *hostsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data hostsResourceModel
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -181,17 +264,17 @@ func (r *hostsResource) Update(ctx context.Context, req resource.UpdateRequest,
 	putURL := fmt.Sprintf("%s/hosts", r.endpoint)
 	tflog.Debug(ctx, "Updating host via TACL", map[string]interface{}{
 		"url":     putURL,
-		"payload": payload,
+		"payload": redactForLog(payload),
 	})
 
 	body, err := doHostsRequest(ctx, r.httpClient, http.MethodPut, putURL, payload)
 	if err != nil {
-		if IsNotFound(err) {
+		if isNotFound(err) {
 			// If not found => remove from state
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Update host error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Update host error", err)
 		return
 	}
 
@@ -201,8 +284,8 @@ func (r *hostsResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	// Overwrite state => ID stays the same
 	data.ID = data.Name
+	data.ServerID = serverObjectID(body)
 	if ipStr, ok := updated["ip"].(string); ok {
 		data.IP = types.StringValue(ipStr)
 	}
@@ -213,6 +296,7 @@ func (r *hostsResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 // Delete => DELETE /hosts => { "name": "hostname" }
 func (r *hostsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data hostsResourceModel
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -220,6 +304,25 @@ func (r *hostsResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	if data.StrictDelete.ValueBool() {
+		serverIP, changed, gone, err := r.changedOutOfBand(ctx, data)
+		if err != nil {
+			addAPIErrorDiagnostic(&resp.Diagnostics, "Read host before delete error", err)
+			return
+		}
+		if gone {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if changed {
+			resp.Diagnostics.AddWarning("Host changed outside Terraform, not deleted",
+				fmt.Sprintf("Host %q now points at %q instead of %q, so it was left in place and only removed from state.",
+					data.Name.ValueString(), serverIP, data.IP.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+	}
+
 	delURL := fmt.Sprintf("%s/hosts", r.endpoint)
 	tflog.Debug(ctx, "Deleting host via TACL", map[string]interface{}{
 		"url":  delURL,
@@ -231,18 +334,40 @@ func (r *hostsResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	_, err := doHostsRequest(ctx, r.httpClient, http.MethodDelete, delURL, payload)
-	if err != nil {
-		if IsNotFound(err) {
-			// already gone
-		} else {
-			resp.Diagnostics.AddError("Delete host error", err.Error())
-			return
-		}
+	if err != nil && !isNotFound(err) {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Delete host error", err)
+		return
 	}
 	// remove from state
 	resp.State.RemoveResource(ctx)
 }
 
+// changedOutOfBand => GET /hosts/:name and compare its IP to state. Returns
+// the server's IP, whether it differs, and gone=true if the host no longer exists.
+func (r *hostsResource) changedOutOfBand(ctx context.Context, data hostsResourceModel) (string, bool, bool, error) {
+	name := data.Name.ValueString()
+	getURL := fmt.Sprintf("%s/hosts/%s", r.endpoint, name)
+	tflog.Debug(ctx, "Reading host before strict delete", map[string]interface{}{
+		"url":  getURL,
+		"name": name,
+	})
+
+	body, err := doHostsRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return "", false, true, nil
+		}
+		return "", false, false, err
+	}
+
+	var fetched map[string]interface{}
+	if err := json.Unmarshal(body, &fetched); err != nil {
+		return "", false, false, fmt.Errorf("parse host response: %w", err)
+	}
+	ip, _ := fetched["ip"].(string)
+	return ip, ip != data.IP.ValueString(), false, nil
+}
+
 // doHostsRequest => helper for /hosts endpoints
 func doHostsRequest(ctx context.Context, client *http.Client, method, url string, payload interface{}) ([]byte, error) {
 	var body io.Reader
@@ -271,7 +396,7 @@ func doHostsRequest(ctx context.Context, client *http.Client, method, url string
 	}
 	if resp.StatusCode >= 300 {
 		msg, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("TACL returned %d: %s", resp.StatusCode, msg)
+		return nil, newAPIError(resp, msg)
 	}
 
 	return io.ReadAll(resp.Body)
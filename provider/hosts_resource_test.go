@@ -0,0 +1,13 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestHostsResource_UpgradeStateV0(t *testing.T) {
+	testNameIDUpgrade(t, NewHostsResource, "hosts", map[string]tftypes.Value{
+		"ip": tfString("192.0.2.10"),
+	})
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure interface compliance
+var _ function.Function = &isCIDRFunction{}
+
+// NewIsCIDRFunction => provider::tacl::is_cidr(s)
+func NewIsCIDRFunction() function.Function {
+	return &isCIDRFunction{}
+}
+
+// isCIDRFunction => true if s parses as an IPv4/IPv6 prefix
+type isCIDRFunction struct{}
+
+func (f *isCIDRFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_cidr"
+}
+
+func (f *isCIDRFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Check whether a string is a valid CIDR",
+		Description: "Returns true if the input parses as an IPv4 or IPv6 prefix such as \"10.0.0.0/8\". Bare IPs return false.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "s",
+				Description: "String to check.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *isCIDRFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var s string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &s))
+	if resp.Error != nil {
+		return
+	}
+
+	_, err := netip.ParsePrefix(s)
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, err == nil))
+}
@@ -7,7 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"strconv"
+	"net/url"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -15,7 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-// nodeattrDataSource => read a single node attribute by numeric index (example).
+// nodeattrDataSource => read a single node attribute by stable ID.
 var (
 	_ datasource.DataSource              = &nodeattrDataSource{}
 	_ datasource.DataSourceWithConfigure = &nodeattrDataSource{}
@@ -28,6 +28,7 @@ func NewNodeAttrDataSource() datasource.DataSource {
 type nodeattrDataSource struct {
 	httpClient *http.Client
 	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
 }
 
 // nodeattrDSModel => we can store target/attr as types.List if we want
@@ -36,6 +37,8 @@ type nodeattrDSModel struct {
 	Target  types.List   `tfsdk:"target"`
 	Attr    types.List   `tfsdk:"attr"`
 	AppJSON types.String `tfsdk:"app_json"`
+
+	FailIfMissing types.Bool `tfsdk:"fail_if_missing"`
 }
 
 func (d *nodeattrDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -46,8 +49,9 @@ func (d *nodeattrDataSource) Configure(ctx context.Context, req datasource.Confi
 	if !ok {
 		return
 	}
-	d.httpClient = p.httpClient
+	d.httpClient = p.dsHTTPClient
 	d.endpoint = p.endpoint
+	d.logMasks = p.logMasks
 }
 
 func (d *nodeattrDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -56,10 +60,14 @@ func (d *nodeattrDataSource) Metadata(ctx context.Context, req datasource.Metada
 
 func (d *nodeattrDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Data source for reading a single node attribute by numeric index (example).",
+		Description: "Data source for reading a single node attribute by stable ID from /nodeattrs/:id.",
 		Attributes: map[string]schema.Attribute{
+			"fail_if_missing": schema.BoolAttribute{
+				Description: "If true, a lookup that finds nothing is an error. Defaults to false: a warning, with attributes left null.",
+				Optional:    true,
+			},
 			"id": schema.StringAttribute{
-				Description: "Index of the node attribute in TACL’s array (example).",
+				Description: "Stable ID of the node attribute in TACL (the same ID the tacl_nodeattr resource exposes).",
 				Required:    true,
 			},
 			"target": schema.ListAttribute{
@@ -80,8 +88,9 @@ func (d *nodeattrDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 	}
 }
 
-// Read => GET /nodeattrs/:index  (example usage).
+// Read => GET /nodeattrs/:id (same stable ID the resource uses)
 func (d *nodeattrDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = maskLogCtx(ctx, d.logMasks)
 	var data nodeattrDSModel
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -89,69 +98,64 @@ func (d *nodeattrDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	idxStr := data.ID.ValueString()
-	idx, err := strconv.Atoi(idxStr)
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Could not parse '%s' as integer", idxStr))
+	id := data.ID.ValueString()
+	if id == "" {
+		resp.Diagnostics.AddError("Missing ID", "Must provide the nodeattr's stable ID for the data source.")
 		return
 	}
 
-	getURL := fmt.Sprintf("%s/nodeattrs/%d", d.endpoint, idx)
+	getURL := fmt.Sprintf("%s/nodeattrs/%s", d.endpoint, url.PathEscape(id))
 	tflog.Debug(ctx, "Reading nodeattr (data source)", map[string]interface{}{
-		"url":   getURL,
-		"index": idx,
+		"url": getURL,
+		"id":  id,
 	})
 
 	body, err := doNodeAttrDSHTTP(ctx, d.httpClient, http.MethodGet, getURL, nil)
 	if err != nil {
-		if IsNotFound(err) {
-			// Not found => do nothing or set empty
-			tflog.Warn(ctx, "No nodeattr found at index", map[string]interface{}{"index": idx})
+		if isNotFound(err) {
+			addDSMissingDiagnostic(&resp.Diagnostics, data.FailIfMissing, "NodeAttr", fmt.Sprintf("with id %q", id))
 			return
 		}
-		resp.Diagnostics.AddError("Read nodeattr DS error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read nodeattr DS error", err)
 		return
 	}
 
-	// Suppose TACL returns JSON like: { "id":"...", "target":["..."], "attr":[] or omitted, "app":{} or omitted }
-	var fetched map[string]interface{}
+	// TACL returns { "id":"...", "target":["..."], "attr":[] or omitted, "app":{} or omitted }
+	var fetched NodeAttrResponse
 	if err := json.Unmarshal(body, &fetched); err != nil {
 		resp.Diagnostics.AddError("Parse DS response error", err.Error())
 		return
 	}
 
-	// If the JSON has "target": [...], "attr": [...], "app": {...}
-	data.ID = types.StringValue(idxStr)
+	data.ID = types.StringValue(fetched.ID)
 
 	// Convert "target"
-	if rawTarget, ok := fetched["target"].([]interface{}); ok {
-		strTarget := interfaceSliceToStringSlice(rawTarget)
-		tfTarget, convErr := stringSliceToList(ctx, strTarget)
-		if convErr == nil {
-			data.Target = tfTarget
-		} else {
+	if fetched.Target != nil {
+		tfTarget, convErr := stringSliceToList(ctx, fetched.Target)
+		if convErr != nil {
 			resp.Diagnostics.AddError("Error converting target list", convErr.Error())
+			return
 		}
+		data.Target = tfTarget
 	} else {
 		data.Target = types.ListNull(types.StringType)
 	}
 
 	// Convert "attr"
-	if rawAttr, ok := fetched["attr"].([]interface{}); ok {
-		strAttr := interfaceSliceToStringSlice(rawAttr)
-		tfAttr, convErr := stringSliceToList(ctx, strAttr)
-		if convErr == nil {
-			data.Attr = tfAttr
-		} else {
+	if fetched.Attr != nil {
+		tfAttr, convErr := stringSliceToList(ctx, fetched.Attr)
+		if convErr != nil {
 			resp.Diagnostics.AddError("Error converting attr list", convErr.Error())
+			return
 		}
+		data.Attr = tfAttr
 	} else {
 		data.Attr = types.ListNull(types.StringType)
 	}
 
 	// Convert "app" => store as JSON
-	if app, ok := fetched["app"]; ok && app != nil {
-		appBytes, _ := json.Marshal(app)
+	if fetched.App != nil {
+		appBytes, _ := json.Marshal(fetched.App)
 		data.AppJSON = types.StringValue(string(appBytes))
 	} else {
 		data.AppJSON = types.StringNull()
@@ -188,20 +192,8 @@ func doNodeAttrDSHTTP(ctx context.Context, client *http.Client, method, url stri
 	}
 	if resp.StatusCode >= 300 {
 		msg, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("TACL returned %d: %s", resp.StatusCode, string(msg))
+		return nil, newAPIError(resp, msg)
 	}
 
 	return io.ReadAll(resp.Body)
 }
-
-// Helpers
-
-func interfaceSliceToStringSlice(in []interface{}) []string {
-	out := make([]string, len(in))
-	for i, v := range in {
-		if s, ok := v.(string); ok {
-			out[i] = s
-		}
-	}
-	return out
-}
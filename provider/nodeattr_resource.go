@@ -7,13 +7,18 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -23,8 +28,9 @@ import (
 // -----------------------------------------------------------------------------
 
 var (
-	_ resource.Resource              = &nodeattrResource{}
-	_ resource.ResourceWithConfigure = &nodeattrResource{}
+	_ resource.Resource                   = &nodeattrResource{}
+	_ resource.ResourceWithConfigure      = &nodeattrResource{}
+	_ resource.ResourceWithValidateConfig = &nodeattrResource{}
 )
 
 // NewNodeAttrResource => constructor
@@ -33,8 +39,10 @@ func NewNodeAttrResource() resource.Resource {
 }
 
 type nodeattrResource struct {
-	httpClient *http.Client
-	endpoint   string
+	httpClient     *http.Client
+	endpoint       string
+	logMasks       []string // provider secrets, masked in tflog (see maskLogCtx)
+	readAfterWrite readAfterWrite
 }
 
 // nodeattrResourceModel => The Terraform schema model.
@@ -44,6 +52,32 @@ type nodeattrResourceModel struct {
 	Target  types.List   `tfsdk:"target"` // Terraform list of strings
 	Attr    types.List   `tfsdk:"attr"`   // Terraform list of strings
 	AppJSON types.String `tfsdk:"app_json"`
+
+	AppConnectors []nodeattrAppConnectorModel `tfsdk:"app_connectors"` // typed "tailscale.com/app-connectors" app
+
+	ForceWildcardTarget types.Bool `tfsdk:"force_wildcard_target"`
+
+	LastModifiedBy types.String `tfsdk:"last_modified_by"` // updatedBy, if TACL records it
+	LastModifiedAt types.String `tfsdk:"last_modified_at"` // updatedAt, if TACL records it
+}
+
+// appConnectorsCap => app capability the `app_connectors` block maps to
+const appConnectorsCap = "tailscale.com/app-connectors"
+
+// nodeattrAppConnectorModel => one `app_connectors` element
+type nodeattrAppConnectorModel struct {
+	Name       types.String   `tfsdk:"name"`
+	Connectors []types.String `tfsdk:"connectors"`
+	Domains    []types.String `tfsdk:"domains"`
+	Routes     []types.String `tfsdk:"routes"`
+}
+
+// appConnector => one element of app["tailscale.com/app-connectors"]
+type appConnector struct {
+	Name       string   `json:"name"`
+	Connectors []string `json:"connectors"`
+	Domains    []string `json:"domains,omitempty"`
+	Routes     []string `json:"routes,omitempty"`
 }
 
 // NodeAttrGrantInput => Request shape for create/update
@@ -59,6 +93,8 @@ type NodeAttrResponse struct {
 	Target []string               `json:"target"`
 	Attr   []string               `json:"attr,omitempty"`
 	App    map[string]interface{} `json:"app,omitempty"`
+
+	auditFields // updatedBy/updatedAt, if TACL records them
 }
 
 // -----------------------------------------------------------------------------
@@ -75,6 +111,8 @@ func (r *nodeattrResource) Configure(ctx context.Context, req resource.Configure
 	}
 	r.httpClient = p.httpClient
 	r.endpoint = p.endpoint
+	r.logMasks = p.logMasks
+	r.readAfterWrite = p.readAfterWrite
 }
 
 func (r *nodeattrResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -88,22 +126,30 @@ func (r *nodeattrResource) Schema(ctx context.Context, req resource.SchemaReques
 			"id": schema.StringAttribute{
 				Description: "TACL's stable ID for this nodeattr.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"target": schema.ListAttribute{
-				Description: "Optional list of targets (the server may overwrite if `app_json` is used).",
+				Description: "Optional list of targets (the server may overwrite if `app_json` is used). If omitted, it's " +
+					"re-read from TACL after switching between `attr` and an app instead of keeping the old value.",
 				Optional:    true,
 				Computed:    true,
 				ElementType: types.StringType,
 				PlanModifiers: []planmodifier.List{
 					// If user omits target => unknown => the server can fill in ["*"] or whatever
-					listplanmodifier.UseStateForUnknown(),
+					nodeattrTargetPlanModifier{},
 				},
 			},
 			"attr": schema.ListAttribute{
-				Description: "Optional list of attributes (mutually exclusive with `app_json`).",
+				Description: "Optional list of attributes (mutually exclusive with `app_json` and `app_connectors`), e.g. 'funnel' or 'nextdns:<profile>'. " +
+					"Empty or malformed entries are rejected at plan time; unrecognized ones only warn.",
 				Optional:    true,
 				Computed:    true,
 				ElementType: types.StringType,
+				Validators: []validator.List{
+					nodeAttrsValidator{},
+				},
 
 				// Default to empty list if user omits
 				Default: listdefault.StaticValue(
@@ -111,18 +157,92 @@ func (r *nodeattrResource) Schema(ctx context.Context, req resource.SchemaReques
 				),
 			},
 			"app_json": schema.StringAttribute{
-				Description: "Optional JSON for `app`. Must be empty if `attr` is used.",
-				Optional:    true,
+				Description: "Optional JSON object for `app`, e.g. `jsonencode({...})`. Checked at plan time; formatting " +
+					"and key order don't cause a diff. Must hold at least one capability, and be empty if `attr` or `app_connectors` is used.",
+				Optional: true,
+				Validators: []validator.String{
+					jsonObjectValidator{},
+				},
 			},
+			"app_connectors": schema.ListNestedAttribute{
+				Description: "Typed alternative to `app_json` for app connectors: sent as the `" + appConnectorsCap + "` app. " +
+					"Mutually exclusive with `attr` and `app_json`.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name of the app connector, e.g. 'github'.",
+							Required:    true,
+						},
+						"connectors": schema.ListAttribute{
+							Description: "Tags of the devices acting as connectors, e.g. ['tag:connector'].",
+							Required:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								nonEmptyListValidator{},
+							},
+						},
+						"domains": schema.ListAttribute{
+							Description: "Domains routed through the connectors, e.g. ['github.com', '*.github.com'].",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"routes": schema.ListAttribute{
+							Description: "Static routes (CIDRs) advertised by the connectors.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+			"force_wildcard_target": schema.BoolAttribute{
+				Description: "When `app_json` or `app_connectors` is used, send target=[\"*\"] instead of `target`. Defaults to true; " +
+					"set false to scope an app grant to specific targets.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"last_modified_by": lastModifiedByAttribute(),
+			"last_modified_at": lastModifiedAtAttribute(),
 		},
 	}
 }
 
+// nodeattrOneSourceMsg => the grant must be exactly one of these
+const nodeattrOneSourceMsg = "Exactly one of `attr`, `app_json` or `app_connectors` must be set."
+
+// ValidateConfig => exactly one of attr, app_json or app_connectors, and a
+// non-empty app, checked at plan time. Unknown values are checked at apply.
+func (r *nodeattrResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var attrs, conns types.List
+	var appJSON types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("attr"), &attrs)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("app_json"), &appJSON)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("app_connectors"), &conns)...)
+	if resp.Diagnostics.HasError() || attrs.IsUnknown() || appJSON.IsUnknown() || conns.IsUnknown() {
+		return
+	}
+
+	if countTrue(len(attrs.Elements()) > 0, appJSONSet(appJSON), len(conns.Elements()) > 0) != 1 {
+		resp.Diagnostics.AddError("Invalid config", nodeattrOneSourceMsg)
+		return
+	}
+	if appJSONSet(appJSON) {
+		// malformed JSON is reported by jsonObjectValidator
+		var app map[string]interface{}
+		if json.Unmarshal([]byte(appJSON.ValueString()), &app) == nil && len(app) == 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("app_json"), "Empty app_json",
+				"`app_json` must hold at least one capability. TACL would store the grant with neither attr nor app.")
+		}
+	}
+}
+
 // -----------------------------------------------------------------------------
 // Create => POST /nodeattrs
 // -----------------------------------------------------------------------------
 
 func (r *nodeattrResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var plan nodeattrResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -130,56 +250,21 @@ func (r *nodeattrResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	// Convert from types.List => []string
-	targetSlice, err := listToStringSlice(ctx, plan.Target)
-	if err != nil {
-		resp.Diagnostics.AddError("Error reading target", err.Error())
-		return
-	}
-	attrSlice, err := listToStringSlice(ctx, plan.Attr)
-	if err != nil {
-		resp.Diagnostics.AddError("Error reading attr", err.Error())
-		return
-	}
-
-	hasAttr := len(attrSlice) > 0
-	hasApp := !plan.AppJSON.IsNull() && plan.AppJSON.ValueString() != ""
-
-	// Exactly one of attr or app must be set
-	if (hasAttr && hasApp) || (!hasAttr && !hasApp) {
-		resp.Diagnostics.AddError("Invalid config",
-			"Exactly one of `attr` or `app_json` must be set.")
+	input, diags := nodeattrGrantFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Build request
-	input := NodeAttrGrantInput{
-		Target: targetSlice,
-	}
-	if hasAttr {
-		input.Attr = attrSlice
-	} else {
-		// parse app JSON
-		var app map[string]interface{}
-		if err := json.Unmarshal([]byte(plan.AppJSON.ValueString()), &app); err != nil {
-			resp.Diagnostics.AddError("Invalid app_json", err.Error())
-			return
-		}
-		input.App = app
-
-		// Option A fix => if app is set, force target=["*"]
-		input.Target = []string{"*"}
-	}
-
 	url := fmt.Sprintf("%s/nodeattrs", r.endpoint)
 	tflog.Debug(ctx, "Creating nodeattr", map[string]interface{}{
 		"url":     url,
-		"payload": input,
+		"payload": redactForLog(input),
 	})
 
 	body, err := doNodeAttrRequest(ctx, r.httpClient, http.MethodPost, url, input)
 	if err != nil {
-		resp.Diagnostics.AddError("Create nodeattr error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Create nodeattr error", err)
 		return
 	}
 
@@ -189,43 +274,27 @@ func (r *nodeattrResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	// Fill final plan from server
-	plan.ID = types.StringValue(created.ID)
-
-	plan.Target, err = stringSliceToList(ctx, created.Target)
+	// Optionally confirm the new nodeattr is readable (replication lag)
+	getURL := fmt.Sprintf("%s/nodeattrs/%s", r.endpoint, created.ID)
+	fetched, err := awaitCreated(ctx, r.readAfterWrite, func() ([]byte, error) {
+		return doNodeAttrRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
+	})
 	if err != nil {
-		resp.Diagnostics.AddError("Error converting target from server", err.Error())
-		return
-	}
-
-	if len(created.Attr) > 0 {
-		// We got an attr-based nodeattr
-		plan.Attr, err = stringSliceToList(ctx, created.Attr)
-		if err != nil {
-			resp.Diagnostics.AddError("Error converting attr from server", err.Error())
+		addReadAfterWriteWarning(&resp.Diagnostics, "nodeattr", err)
+	} else if fetched != nil {
+		// decode fresh, so fields the GET omits don't survive from the POST
+		var confirmed NodeAttrResponse
+		if e := json.Unmarshal(fetched, &confirmed); e != nil {
+			resp.Diagnostics.AddError("Parse read response error", e.Error())
 			return
 		}
-		plan.AppJSON = types.StringNull()
-	} else if created.App != nil {
-		// We got an app-based nodeattr
-		b, _ := json.Marshal(created.App)
-		plan.AppJSON = types.StringValue(string(b))
-
-		emptyList, diags2 := types.ListValue(types.StringType, []attr.Value{})
-		resp.Diagnostics.Append(diags2...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		plan.Attr = emptyList
-	} else {
-		// Neither attr nor app returned -> set them empty
-		emptyList, diags2 := types.ListValue(types.StringType, []attr.Value{})
-		resp.Diagnostics.Append(diags2...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		plan.Attr = emptyList
-		plan.AppJSON = types.StringNull()
+		created = confirmed
+	}
+
+	// Fill final plan from server
+	resp.Diagnostics.Append(setNodeAttrState(ctx, &plan, created)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	diags = resp.State.Set(ctx, &plan)
@@ -237,6 +306,7 @@ func (r *nodeattrResource) Create(ctx context.Context, req resource.CreateReques
 // -----------------------------------------------------------------------------
 
 func (r *nodeattrResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var state nodeattrResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -262,7 +332,7 @@ func (r *nodeattrResource) Read(ctx context.Context, req resource.ReadRequest, r
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Read nodeattr error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read nodeattr error", err)
 		return
 	}
 
@@ -272,40 +342,14 @@ func (r *nodeattrResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	state.ID = types.StringValue(fetched.ID)
-
-	// Convert from []string => types.List
-	state.Target, err = stringSliceToList(ctx, fetched.Target)
-	if err != nil {
-		resp.Diagnostics.AddError("Error converting target from server", err.Error())
-		return
+	// Not stored server-side; state from before this attribute existed is null
+	if state.ForceWildcardTarget.IsNull() {
+		state.ForceWildcardTarget = types.BoolValue(true)
 	}
 
-	if len(fetched.Attr) > 0 {
-		state.Attr, err = stringSliceToList(ctx, fetched.Attr)
-		if err != nil {
-			resp.Diagnostics.AddError("Error converting attr from server", err.Error())
-			return
-		}
-		state.AppJSON = types.StringNull()
-	} else if fetched.App != nil {
-		b, _ := json.Marshal(fetched.App)
-		state.AppJSON = types.StringValue(string(b))
-
-		emptyList, diags2 := types.ListValue(types.StringType, []attr.Value{})
-		resp.Diagnostics.Append(diags2...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		state.Attr = emptyList
-	} else {
-		emptyList, diags2 := types.ListValue(types.StringType, []attr.Value{})
-		resp.Diagnostics.Append(diags2...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		state.Attr = emptyList
-		state.AppJSON = types.StringNull()
+	resp.Diagnostics.Append(setNodeAttrState(ctx, &state, fetched)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	diags = resp.State.Set(ctx, &state)
@@ -317,6 +361,7 @@ func (r *nodeattrResource) Read(ctx context.Context, req resource.ReadRequest, r
 // -----------------------------------------------------------------------------
 
 func (r *nodeattrResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var oldState nodeattrResourceModel
 	diags := req.State.Get(ctx, &oldState)
 	resp.Diagnostics.Append(diags...)
@@ -339,42 +384,12 @@ func (r *nodeattrResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	targetSlice, err := listToStringSlice(ctx, plan.Target)
-	if err != nil {
-		resp.Diagnostics.AddError("Error reading target", err.Error())
-		return
-	}
-	attrSlice, err := listToStringSlice(ctx, plan.Attr)
-	if err != nil {
-		resp.Diagnostics.AddError("Error reading attr", err.Error())
-		return
-	}
-
-	hasAttr := len(attrSlice) > 0
-	hasApp := !plan.AppJSON.IsNull() && plan.AppJSON.ValueString() != ""
-	if (hasAttr && hasApp) || (!hasAttr && !hasApp) {
-		resp.Diagnostics.AddError("Invalid config",
-			"Exactly one of `attr` or `app_json` must be set.")
+	input, diags := nodeattrGrantFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	input := NodeAttrGrantInput{
-		Target: targetSlice,
-	}
-	if hasAttr {
-		input.Attr = attrSlice
-	} else {
-		var app map[string]interface{}
-		if err := json.Unmarshal([]byte(plan.AppJSON.ValueString()), &app); err != nil {
-			resp.Diagnostics.AddError("Invalid app_json", err.Error())
-			return
-		}
-		input.App = app
-
-		// Option A fix => if app is used, force Target=["*"]
-		input.Target = []string{"*"}
-	}
-
 	payload := map[string]interface{}{
 		"id":    id,
 		"grant": input,
@@ -383,7 +398,7 @@ func (r *nodeattrResource) Update(ctx context.Context, req resource.UpdateReques
 	url := fmt.Sprintf("%s/nodeattrs", r.endpoint)
 	tflog.Debug(ctx, "Updating nodeattr", map[string]interface{}{
 		"url":     url,
-		"payload": payload,
+		"payload": redactForLog(payload),
 	})
 
 	body, err := doNodeAttrRequest(ctx, r.httpClient, http.MethodPut, url, payload)
@@ -392,7 +407,7 @@ func (r *nodeattrResource) Update(ctx context.Context, req resource.UpdateReques
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Update nodeattr error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Update nodeattr error", err)
 		return
 	}
 
@@ -402,47 +417,18 @@ func (r *nodeattrResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	plan.ID = types.StringValue(updated.ID)
-
-	plan.Target, err = stringSliceToList(ctx, updated.Target)
-	if err != nil {
-		resp.Diagnostics.AddError("Error converting target from server", err.Error())
+	resp.Diagnostics.Append(setNodeAttrState(ctx, &plan, updated)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if len(updated.Attr) > 0 {
-		plan.Attr, err = stringSliceToList(ctx, updated.Attr)
-		if err != nil {
-			resp.Diagnostics.AddError("Error converting attr from server", err.Error())
-			return
-		}
-		plan.AppJSON = types.StringNull()
-	} else if updated.App != nil {
-		b, _ := json.Marshal(updated.App)
-		plan.AppJSON = types.StringValue(string(b))
-
-		emptyList, diags2 := types.ListValue(types.StringType, []attr.Value{})
-		resp.Diagnostics.Append(diags2...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		plan.Attr = emptyList
-	} else {
-		emptyList, diags2 := types.ListValue(types.StringType, []attr.Value{})
-		resp.Diagnostics.Append(diags2...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		plan.Attr = emptyList
-		plan.AppJSON = types.StringNull()
-	}
-
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 }
 
 // Delete => no changes from your last version
 func (r *nodeattrResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data nodeattrResourceModel
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -460,17 +446,13 @@ func (r *nodeattrResource) Delete(ctx context.Context, req resource.DeleteReques
 	url := fmt.Sprintf("%s/nodeattrs", r.endpoint)
 	tflog.Debug(ctx, "Deleting nodeattr by ID", map[string]interface{}{
 		"url":     url,
-		"payload": payload,
+		"payload": redactForLog(payload),
 	})
 
 	_, err := doNodeAttrRequest(ctx, r.httpClient, http.MethodDelete, url, payload)
-	if err != nil {
-		if isNotFound(err) {
-			// already gone
-		} else {
-			resp.Diagnostics.AddError("Delete nodeattr error", err.Error())
-			return
-		}
+	if err != nil && !isNotFound(err) {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Delete nodeattr error", err)
+		return
 	}
 	resp.State.RemoveResource(ctx)
 }
@@ -479,6 +461,223 @@ func (r *nodeattrResource) Delete(ctx context.Context, req resource.DeleteReques
 // Helper Functions
 // -----------------------------------------------------------------------------
 
+// countTrue => how many of bs are true
+func countTrue(bs ...bool) int {
+	n := 0
+	for _, b := range bs {
+		if b {
+			n++
+		}
+	}
+	return n
+}
+
+// appJSONSet => app_json carries a value ("" counts as unset)
+func appJSONSet(v types.String) bool {
+	return !v.IsNull() && !v.IsUnknown() && v.ValueString() != ""
+}
+
+// nodeattrGrantFromPlan => the grant to send: attr, or an app built from
+// app_json/app_connectors. ValidateConfig already checked there's exactly
+// one; checked again here for values that were unknown at plan time.
+func nodeattrGrantFromPlan(ctx context.Context, plan nodeattrResourceModel) (NodeAttrGrantInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	targetSlice, err := listToStringSlice(ctx, plan.Target)
+	if err != nil {
+		diags.AddError("Error reading target", err.Error())
+		return NodeAttrGrantInput{}, diags
+	}
+	attrSlice, err := listToStringSlice(ctx, plan.Attr)
+	if err != nil {
+		diags.AddError("Error reading attr", err.Error())
+		return NodeAttrGrantInput{}, diags
+	}
+
+	if countTrue(len(attrSlice) > 0, appJSONSet(plan.AppJSON), len(plan.AppConnectors) > 0) != 1 {
+		diags.AddError("Invalid config", nodeattrOneSourceMsg)
+		return NodeAttrGrantInput{}, diags
+	}
+
+	input := NodeAttrGrantInput{Target: targetSlice}
+	if len(attrSlice) > 0 {
+		input.Attr = attrSlice
+		return input, diags
+	}
+
+	app, err := nodeattrAppPayload(plan)
+	if err != nil {
+		diags.AddAttributeError(path.Root("app_json"), "Invalid app_json", err.Error())
+		return NodeAttrGrantInput{}, diags
+	}
+	input.App = app
+
+	// Option A fix => if app is set, force target=["*"] unless opted out
+	if plan.ForceWildcardTarget.ValueBool() {
+		input.Target = []string{"*"}
+	} else if len(targetSlice) == 0 {
+		diags.AddError("Invalid config",
+			"`target` must be set when `force_wildcard_target` is false.")
+		return NodeAttrGrantInput{}, diags
+	}
+	return input, diags
+}
+
+// setNodeAttrState => copy a TACL response into m. Each source reflects only
+// what the server reports, so switching between attr and an app leaves
+// nothing behind: attr is [] (its default) without attributes, and
+// app_json/app_connectors are null without an app. Should TACL report both,
+// both are kept so the next plan shows it.
+func setNodeAttrState(ctx context.Context, m *nodeattrResourceModel, res NodeAttrResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(res.ID)
+	m.LastModifiedBy, m.LastModifiedAt = res.auditValues()
+
+	var err error
+	m.Target, err = stringSliceToList(ctx, res.Target)
+	if err != nil {
+		diags.AddError("Error converting target from server", err.Error())
+		return diags
+	}
+	m.Attr, err = stringSliceToList(ctx, res.Attr)
+	if err != nil {
+		diags.AddError("Error converting attr from server", err.Error())
+		return diags
+	}
+
+	if len(res.App) > 0 {
+		m.AppJSON, m.AppConnectors = nodeattrAppOrPrior(res.App, m.AppJSON, m.AppConnectors)
+	} else {
+		m.AppJSON = types.StringNull()
+		m.AppConnectors = nil
+	}
+	return diags
+}
+
+// nodeattrAppPayload => the app map to send: app_json parsed, or the
+// app_connectors block under appConnectorsCap
+func nodeattrAppPayload(plan nodeattrResourceModel) (map[string]interface{}, error) {
+	if len(plan.AppConnectors) == 0 {
+		var app map[string]interface{}
+		if err := json.Unmarshal([]byte(plan.AppJSON.ValueString()), &app); err != nil {
+			return nil, err
+		}
+		if len(app) == 0 {
+			return nil, fmt.Errorf("app_json must hold at least one capability")
+		}
+		return app, nil
+	}
+	conns := make([]appConnector, len(plan.AppConnectors))
+	for i, c := range plan.AppConnectors {
+		conns[i] = appConnector{
+			Name:       c.Name.ValueString(),
+			Connectors: toStringSlice(c.Connectors),
+			Domains:    toStringSlice(c.Domains),
+			Routes:     toStringSlice(c.Routes),
+		}
+	}
+	return map[string]interface{}{appConnectorsCap: conns}, nil
+}
+
+// nodeattrAppOrPrior => state for the server's app: back into app_connectors
+// if that's how it was configured and the app still has exactly that shape,
+// otherwise as app_json (see appJSONOrPrior)
+func nodeattrAppOrPrior(app map[string]interface{}, priorJSON types.String, priorConns []nodeattrAppConnectorModel) (types.String, []nodeattrAppConnectorModel) {
+	if len(priorConns) > 0 {
+		if conns, ok := appConnectorsFromApp(app); ok {
+			out := make([]nodeattrAppConnectorModel, len(conns))
+			for i, c := range conns {
+				var prior nodeattrAppConnectorModel
+				if i < len(priorConns) {
+					prior = priorConns[i]
+				}
+				out[i] = nodeattrAppConnectorModel{
+					Name:       types.StringValue(c.Name),
+					Connectors: normalizedOrPrior(c.Connectors, prior.Connectors),
+					Domains:    optionalListOrPrior(c.Domains, prior.Domains),
+					Routes:     optionalListOrPrior(c.Routes, prior.Routes),
+				}
+			}
+			return types.StringNull(), out
+		}
+	}
+	return appJSONOrPrior(app, priorJSON), nil
+}
+
+// nodeattrTargetPlanModifier => UseStateForUnknown for an omitted target,
+// except when the grant switches between attr and an app or
+// force_wildcard_target changes: TACL fills target differently then (["*"]
+// for wildcard apps), so the old value would linger in the plan.
+type nodeattrTargetPlanModifier struct{}
+
+var _ planmodifier.List = nodeattrTargetPlanModifier{}
+
+func (m nodeattrTargetPlanModifier) Description(ctx context.Context) string {
+	return "Keeps the prior target unless the grant switches between attr and an app."
+}
+
+func (m nodeattrTargetPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return "Keeps the prior target unless the grant switches between `attr` and an app."
+}
+
+func (m nodeattrTargetPlanModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	var planAttr, stateAttr types.List
+	var planWildcard, stateWildcard types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("attr"), &planAttr)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("attr"), &stateAttr)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("force_wildcard_target"), &planWildcard)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("force_wildcard_target"), &stateWildcard)...)
+	if resp.Diagnostics.HasError() || planAttr.IsUnknown() || planWildcard.IsUnknown() {
+		return
+	}
+	if (len(planAttr.Elements()) > 0) != (len(stateAttr.Elements()) > 0) {
+		return
+	}
+	// state from before force_wildcard_target existed is null, i.e. true
+	if planWildcard.ValueBool() != (stateWildcard.IsNull() || stateWildcard.ValueBool()) {
+		return
+	}
+	resp.PlanValue = req.StateValue
+}
+
+// appConnectorsFromApp => app's connectors when appConnectorsCap is its only
+// key and decodes cleanly
+func appConnectorsFromApp(app map[string]interface{}) ([]appConnector, bool) {
+	raw, ok := app[appConnectorsCap]
+	if !ok || len(app) != 1 {
+		return nil, false
+	}
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, false
+	}
+	var conns []appConnector
+	if json.Unmarshal(b, &conns) != nil || len(conns) == 0 {
+		return nil, false
+	}
+	return conns, true
+}
+
+// appJSONOrPrior => the server's app as JSON, unless prior already encodes the
+// same value (TACL re-serializes app, so whitespace and key order change).
+func appJSONOrPrior(app map[string]interface{}, prior types.String) types.String {
+	b, err := json.Marshal(app)
+	if err != nil {
+		return prior
+	}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		var priorApp map[string]interface{}
+		if json.Unmarshal([]byte(prior.ValueString()), &priorApp) == nil && reflect.DeepEqual(priorApp, app) {
+			return prior
+		}
+	}
+	return types.StringValue(string(b))
+}
+
 func doNodeAttrRequest(ctx context.Context, client *http.Client, method, url string, payload interface{}) ([]byte, error) {
 	var body io.Reader
 	if payload != nil {
@@ -506,7 +705,7 @@ func doNodeAttrRequest(ctx context.Context, client *http.Client, method, url str
 	}
 	if resp.StatusCode >= 300 {
 		msg, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("TACL returned %d: %s", resp.StatusCode, string(msg))
+		return nil, newAPIError(resp, msg)
 	}
 	return io.ReadAll(resp.Body)
 }
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSetNodeAttrState_Switching(t *testing.T) {
+	ctx := context.Background()
+	funnel, _ := stringSliceToList(ctx, []string{"funnel"})
+	tests := []struct {
+		name        string
+		prior       nodeattrResourceModel
+		res         NodeAttrResponse
+		wantAttr    int
+		wantAppJSON bool
+		wantConns   int
+	}{
+		{
+			name:        "attr to app_json",
+			prior:       nodeattrResourceModel{Attr: funnel, AppJSON: types.StringNull()},
+			res:         NodeAttrResponse{ID: "n1", Target: []string{"*"}, App: map[string]interface{}{"tailscale.com/cap/x": []interface{}{}}},
+			wantAppJSON: true,
+		},
+		{
+			name: "attr to app_connectors",
+			prior: nodeattrResourceModel{Attr: funnel, AppConnectors: []nodeattrAppConnectorModel{{
+				Name: types.StringValue("github"), Connectors: []types.String{types.StringValue("tag:connector")},
+			}}},
+			res: NodeAttrResponse{ID: "n1", Target: []string{"*"}, App: map[string]interface{}{
+				appConnectorsCap: []interface{}{map[string]interface{}{"name": "github", "connectors": []interface{}{"tag:connector"}}},
+			}},
+			wantConns: 1,
+		},
+		{
+			name:     "app_json to attr",
+			prior:    nodeattrResourceModel{Attr: types.ListValueMust(types.StringType, nil), AppJSON: types.StringValue(`{"tailscale.com/cap/x":[]}`)},
+			res:      NodeAttrResponse{ID: "n1", Target: []string{"tag:web"}, Attr: []string{"funnel"}},
+			wantAttr: 1,
+		},
+		{
+			name: "app_connectors to attr",
+			prior: nodeattrResourceModel{AppConnectors: []nodeattrAppConnectorModel{{
+				Name: types.StringValue("github"), Connectors: []types.String{types.StringValue("tag:connector")},
+			}}},
+			res:      NodeAttrResponse{ID: "n1", Target: []string{"tag:web"}, Attr: []string{"funnel"}},
+			wantAttr: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.prior
+			requireNoErrors(t, setNodeAttrState(ctx, &m, tt.res))
+			if m.Attr.IsNull() || len(m.Attr.Elements()) != tt.wantAttr {
+				t.Errorf("attr = %v, want %d element(s)", m.Attr, tt.wantAttr)
+			}
+			if m.AppJSON.IsNull() == tt.wantAppJSON {
+				t.Errorf("app_json = %v, want set %v", m.AppJSON, tt.wantAppJSON)
+			}
+			if len(m.AppConnectors) != tt.wantConns {
+				t.Errorf("app_connectors = %v, want %d", m.AppConnectors, tt.wantConns)
+			}
+			if len(m.Target.Elements()) != len(tt.res.Target) {
+				t.Errorf("target = %v, want %v", m.Target, tt.res.Target)
+			}
+		})
+	}
+}
+
+func TestNodeAttrTargetPlanModifier(t *testing.T) {
+	ctx := context.Background()
+	r := newTestResource(t, &taclProvider{}, NewNodeAttrResource())
+	typ := r.schema.Type().TerraformType(ctx)
+	unknownTarget := tftypes.NewValue(listOf(tftypes.String), tftypes.UnknownValue)
+	noAttr := tfStrings(listOf)
+
+	tests := []struct {
+		name          string
+		stateAttr     tftypes.Value
+		planAttr      tftypes.Value
+		stateWildcard tftypes.Value
+		planWildcard  tftypes.Value
+		wantKept      bool
+	}{
+		{name: "attr unchanged", stateAttr: tfStrings(listOf, "funnel"), planAttr: tfStrings(listOf, "funnel"), wantKept: true},
+		{name: "app unchanged", stateAttr: noAttr, planAttr: noAttr, wantKept: true},
+		{name: "attr to app", stateAttr: tfStrings(listOf, "funnel"), planAttr: noAttr},
+		{name: "app to attr", stateAttr: noAttr, planAttr: tfStrings(listOf, "funnel")},
+		{name: "wildcard turned off", stateAttr: noAttr, planAttr: noAttr, planWildcard: tfBool(false)},
+		{name: "wildcard from old state", stateAttr: noAttr, planAttr: noAttr,
+			stateWildcard: tftypes.NewValue(tftypes.Bool, nil), wantKept: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateWildcard, planWildcard := tt.stateWildcard, tt.planWildcard
+			if stateWildcard.Type() == nil {
+				stateWildcard = tfBool(true)
+			}
+			if planWildcard.Type() == nil {
+				planWildcard = tfBool(true)
+			}
+			state := tfsdk.State{Schema: r.schema, Raw: objectValue(t, typ, map[string]tftypes.Value{
+				"id":                    tfString("n1"),
+				"target":                tfStrings(listOf, "tag:web"),
+				"attr":                  tt.stateAttr,
+				"force_wildcard_target": stateWildcard,
+			}, nil)}
+			plan := tfsdk.Plan{Schema: r.schema, Raw: objectValue(t, typ, map[string]tftypes.Value{
+				"id":                    tfString("n1"),
+				"target":                unknownTarget,
+				"attr":                  tt.planAttr,
+				"force_wildcard_target": planWildcard,
+			}, nil)}
+
+			stateTarget, _ := stringSliceToList(ctx, []string{"tag:web"})
+			req := planmodifier.ListRequest{
+				Path:        path.Root("target"),
+				ConfigValue: types.ListNull(types.StringType),
+				StateValue:  stateTarget,
+				PlanValue:   types.ListUnknown(types.StringType),
+				State:       state,
+				Plan:        plan,
+			}
+			resp := planmodifier.ListResponse{PlanValue: req.PlanValue}
+			nodeattrTargetPlanModifier{}.PlanModifyList(ctx, req, &resp)
+			requireNoErrors(t, resp.Diagnostics)
+
+			if kept := !resp.PlanValue.IsUnknown(); kept != tt.wantKept {
+				t.Fatalf("target = %v, want prior kept %v", resp.PlanValue, tt.wantKept)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// nodeattrsDataSource => list every node attribute grant in /nodeattrs
+var (
+	_ datasource.DataSource              = &nodeattrsDataSource{}
+	_ datasource.DataSourceWithConfigure = &nodeattrsDataSource{}
+)
+
+func NewNodeAttrsDataSource() datasource.DataSource {
+	return &nodeattrsDataSource{}
+}
+
+type nodeattrsDataSource struct {
+	httpClient *http.Client
+	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
+}
+
+type nodeattrsDSModel struct {
+	ID        types.String `tfsdk:"id"`
+	NodeAttrs types.List   `tfsdk:"nodeattrs"`
+}
+
+// nodeattrsEntryAttrTypes => object type of one element of `nodeattrs`
+var nodeattrsEntryAttrTypes = map[string]attr.Type{
+	"id":       types.StringType,
+	"target":   types.ListType{ElemType: types.StringType},
+	"attr":     types.ListType{ElemType: types.StringType},
+	"app_json": types.StringType,
+}
+
+func (d *nodeattrsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p, ok := req.ProviderData.(*taclProvider)
+	if !ok {
+		return
+	}
+	d.httpClient = p.dsHTTPClient
+	d.endpoint = p.endpoint
+	d.logMasks = p.logMasks
+}
+
+func (d *nodeattrsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nodeattrs"
+}
+
+func (d *nodeattrsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source listing every node attribute grant in /nodeattrs, sorted by ID.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Always 'nodeattrs'.",
+				Computed:    true,
+			},
+			"nodeattrs": schema.ListNestedAttribute{
+				Description: "All node attribute grants, sorted by ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Stable ID of the node attribute in TACL.",
+							Computed:    true,
+						},
+						"target": schema.ListAttribute{
+							Description: "List of target strings.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"attr": schema.ListAttribute{
+							Description: "List of attribute strings, if any.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"app_json": schema.StringAttribute{
+							Description: "If present, TACL's 'app' data as JSON.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read => GET /nodeattrs (following pagination)
+func (d *nodeattrsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = maskLogCtx(ctx, d.logMasks)
+	var data nodeattrsDSModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listURL := fmt.Sprintf("%s/nodeattrs", d.endpoint)
+	tflog.Debug(ctx, "Listing nodeattrs (data source)", map[string]interface{}{
+		"url": listURL,
+	})
+
+	items, err := doListRequest(ctx, d.httpClient, listURL)
+	if err != nil && !isNotFound(err) {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "List nodeattrs DS error", err)
+		return
+	}
+
+	fetched := make([]NodeAttrResponse, 0, len(items))
+	for _, raw := range items {
+		var na NodeAttrResponse
+		if err := json.Unmarshal(raw, &na); err != nil {
+			resp.Diagnostics.AddError("Parse DS response error", err.Error())
+			return
+		}
+		fetched = append(fetched, na)
+	}
+	sort.SliceStable(fetched, func(i, j int) bool { return fetched[i].ID < fetched[j].ID })
+
+	elems := make([]attr.Value, 0, len(fetched))
+	for _, na := range fetched {
+		obj, objDiags := nodeattrToObject(ctx, na)
+		resp.Diagnostics.Append(objDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		elems = append(elems, obj)
+	}
+
+	list, listDiags := types.ListValue(types.ObjectType{AttrTypes: nodeattrsEntryAttrTypes}, elems)
+	resp.Diagnostics.Append(listDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue("nodeattrs")
+	data.NodeAttrs = list
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// nodeattrToObject => one `nodeattrs` element. Missing target/attr/app are null.
+func nodeattrToObject(ctx context.Context, na NodeAttrResponse) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	target := types.ListNull(types.StringType)
+	if na.Target != nil {
+		v, d := types.ListValueFrom(ctx, types.StringType, na.Target)
+		diags.Append(d...)
+		target = v
+	}
+	attrs := types.ListNull(types.StringType)
+	if na.Attr != nil {
+		v, d := types.ListValueFrom(ctx, types.StringType, na.Attr)
+		diags.Append(d...)
+		attrs = v
+	}
+	appJSON := types.StringNull()
+	if na.App != nil {
+		b, err := json.Marshal(na.App)
+		if err != nil {
+			diags.AddError("Error encoding app", err.Error())
+			return types.ObjectNull(nodeattrsEntryAttrTypes), diags
+		}
+		appJSON = types.StringValue(string(b))
+	}
+	if diags.HasError() {
+		return types.ObjectNull(nodeattrsEntryAttrTypes), diags
+	}
+
+	obj, d := types.ObjectValue(nodeattrsEntryAttrTypes, map[string]attr.Value{
+		"id":       types.StringValue(na.ID),
+		"target":   target,
+		"attr":     attrs,
+		"app_json": appJSON,
+	})
+	diags.Append(d...)
+	return obj, diags
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure interface compliance
+var _ function.Function = &normalizeCIDRFunction{}
+
+// NewNormalizeCIDRFunction => provider::tacl::normalize_cidr(s)
+func NewNormalizeCIDRFunction() function.Function {
+	return &normalizeCIDRFunction{}
+}
+
+// normalizeCIDRFunction => canonical form of a CIDR, e.g. "10.0.0.1/8" => "10.0.0.0/8"
+type normalizeCIDRFunction struct{}
+
+func (f *normalizeCIDRFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_cidr"
+}
+
+func (f *normalizeCIDRFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Return the canonical form of a CIDR",
+		Description: "Masks off host bits and canonicalizes the address, e.g. \"10.0.0.1/8\" => \"10.0.0.0/8\". " +
+			"A bare IP is returned as a single-host prefix (/32 or /128). Errors on anything else.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "s",
+				Description: "CIDR or IP address to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *normalizeCIDRFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var s string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &s))
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := normalizeCIDR(s)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, normalized))
+}
+
+// normalizeCIDR => shared by the function and anything else that wants a canonical prefix
+func normalizeCIDR(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a valid CIDR: %s", s, err)
+		}
+		return prefix.Masked().String(), nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return "", fmt.Errorf("%q is not a valid CIDR or IP address: %s", s, err)
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()).String(), nil
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxListPages => guard against a server that keeps handing out the same cursor
+const maxListPages = 1000
+
+// listPage => the enveloped shape a paginating TACL list endpoint may return.
+// A plain JSON array is treated as a single, final page.
+type listPage struct {
+	Items      []json.RawMessage `json:"items"`
+	Data       []json.RawMessage `json:"data"`
+	Next       string            `json:"next"`
+	NextCursor string            `json:"nextCursor"`
+}
+
+// doListRequest => GET a list endpoint and follow pagination until the last
+// page, returning every element. Supports both an RFC 5988 Link header with
+// rel="next" and a `next`/`nextCursor` field in an enveloped JSON body. A
+// `next` that isn't a URL is treated as a cursor and sent as ?cursor=.
+func doListRequest(ctx context.Context, client *http.Client, listURL string) ([]json.RawMessage, error) {
+	var all []json.RawMessage
+	seen := map[string]bool{}
+
+	pageURL := listURL
+	for page := 0; pageURL != ""; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if page >= maxListPages {
+			return nil, fmt.Errorf("list %s: gave up after %d pages", listURL, maxListPages)
+		}
+		if seen[pageURL] {
+			return nil, fmt.Errorf("list %s: server returned a pagination loop at %s", listURL, pageURL)
+		}
+		seen[pageURL] = true
+
+		items, next, err := doListPageRequest(ctx, client, pageURL)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+
+		pageURL, err = resolveNextPage(listURL, pageURL, next)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return all, nil
+}
+
+func doListPageRequest(ctx context.Context, client *http.Client, pageURL string) ([]json.RawMessage, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("list request creation error: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("list request error: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return nil, "", &NotFoundError{Message: "list not found"}
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read list response: %w", err)
+	}
+	if res.StatusCode >= 300 {
+		return nil, "", newAPIError(res, body)
+	}
+
+	next := nextFromLinkHeader(res.Header.Get("Link"))
+
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "[") {
+		var items []json.RawMessage
+		if err := json.Unmarshal(body, &items); err != nil {
+			return nil, "", fmt.Errorf("parse list response: %w", err)
+		}
+		return items, next, nil
+	}
+
+	var env listPage
+	if err := json.Unmarshal(body, &env); err != nil {
+		return nil, "", fmt.Errorf("parse list response: %w", err)
+	}
+	items := env.Items
+	if items == nil {
+		items = env.Data
+	}
+	if next == "" {
+		next = env.Next
+	}
+	if next == "" {
+		next = env.NextCursor
+	}
+	return items, next, nil
+}
+
+// nextFromLinkHeader => pull the rel="next" target out of a Link header
+func nextFromLinkHeader(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		segs := strings.Split(part, ";")
+		if len(segs) < 2 {
+			continue
+		}
+		target := strings.Trim(strings.TrimSpace(segs[0]), "<>")
+		for _, attr := range segs[1:] {
+			attr = strings.ReplaceAll(strings.TrimSpace(attr), " ", "")
+			if attr == `rel="next"` || attr == "rel=next" {
+				return target
+			}
+		}
+	}
+	return ""
+}
+
+// resolveNextPage => turn `next` (absolute URL, relative URL, or bare cursor)
+// into the URL of the next page. Empty next => "" (done).
+func resolveNextPage(listURL, currentURL, next string) (string, error) {
+	if next == "" {
+		return "", nil
+	}
+
+	if strings.Contains(next, "/") || strings.Contains(next, "?") {
+		base, err := url.Parse(currentURL)
+		if err != nil {
+			return "", fmt.Errorf("parse page URL: %w", err)
+		}
+		ref, err := url.Parse(next)
+		if err != nil {
+			return "", fmt.Errorf("parse next page URL %q: %w", next, err)
+		}
+		return base.ResolveReference(ref).String(), nil
+	}
+
+	// bare cursor => listURL?cursor=<next>
+	u, err := url.Parse(listURL)
+	if err != nil {
+		return "", fmt.Errorf("parse list URL: %w", err)
+	}
+	q := u.Query()
+	q.Set("cursor", next)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tailscale/hujson"
+)
+
+// Ensure interface compliance
+var _ function.Function = &parseACLsFunction{}
+
+// NewParseACLsFunction => provider::tacl::parse_acls(hujson)
+func NewParseACLsFunction() function.Function {
+	return &parseACLsFunction{}
+}
+
+// parseACLsFunction => pulls the "acls" array out of a Tailscale policy file
+// so it can be fed into for_each over tacl_acl.
+type parseACLsFunction struct{}
+
+// parsedACLAttrTypes => one element of the returned list; mirrors tacl_acl
+var parsedACLAttrTypes = map[string]attr.Type{
+	"action": types.StringType,
+	"src":    types.ListType{ElemType: types.StringType},
+	"proto":  types.StringType,
+	"dst":    types.ListType{ElemType: types.StringType},
+}
+
+// parsedACL => tfsdk shape of parsedACLAttrTypes
+type parsedACL struct {
+	Action types.String `tfsdk:"action"`
+	Src    []string     `tfsdk:"src"`
+	Proto  types.String `tfsdk:"proto"`
+	Dst    []string     `tfsdk:"dst"`
+}
+
+// policyFileACL => an ACL as written in a policy file. Older files use
+// users/ports instead of src/dst.
+type policyFileACL struct {
+	Action string   `json:"action"`
+	Src    []string `json:"src"`
+	Proto  string   `json:"proto"`
+	Dst    []string `json:"dst"`
+	Users  []string `json:"users"`
+	Ports  []string `json:"ports"`
+}
+
+func (f *parseACLsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_acls"
+}
+
+func (f *parseACLsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse the ACLs out of a HuJSON policy file",
+		Description: "Parses a Tailscale policy file (HuJSON, so comments and trailing commas are fine) and returns its " +
+			"\"acls\" as a list of objects with action, src, proto and dst, ready for for_each over tacl_acl. " +
+			"Legacy users/ports fields are mapped to src/dst. proto is null when unset.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "hujson",
+				Description: "Contents of the policy file, e.g. file(\"policy.hujson\").",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.ObjectType{AttrTypes: parsedACLAttrTypes},
+		},
+	}
+}
+
+func (f *parseACLsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var doc string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &doc))
+	if resp.Error != nil {
+		return
+	}
+
+	entries, err := parsePolicyACLs([]byte(doc))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, entries))
+}
+
+// parsePolicyACLs => HuJSON policy => its ACL entries, in file order
+func parsePolicyACLs(doc []byte) ([]parsedACL, error) {
+	std, err := hujson.Standardize(doc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HuJSON: %s", err)
+	}
+
+	var policy struct {
+		ACLs []policyFileACL `json:"acls"`
+	}
+	if err := json.Unmarshal(std, &policy); err != nil {
+		return nil, fmt.Errorf("invalid policy file: %s", err)
+	}
+
+	out := make([]parsedACL, 0, len(policy.ACLs))
+	for i, a := range policy.ACLs {
+		src, dst := a.Src, a.Dst
+		if len(src) == 0 {
+			src = a.Users
+		}
+		if len(dst) == 0 {
+			dst = a.Ports
+		}
+		if a.Action == "" || len(src) == 0 || len(dst) == 0 {
+			return nil, fmt.Errorf("acls[%d]: action, src (or users) and dst (or ports) are required", i)
+		}
+		out = append(out, parsedACL{
+			Action: types.StringValue(a.Action),
+			Src:    src,
+			Proto:  stringOrNull(a.Proto),
+			Dst:    dst,
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure DS compliance
+var (
+	_ datasource.DataSource              = &policyDataSource{}
+	_ datasource.DataSourceWithConfigure = &policyDataSource{}
+)
+
+// NewPolicyDataSource => "tacl_policy" data source
+func NewPolicyDataSource() datasource.DataSource {
+	return &policyDataSource{}
+}
+
+type policyDataSource struct {
+	httpClient *http.Client
+	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
+}
+
+type policyDSModel struct {
+	ID     types.String `tfsdk:"id"`
+	Path   types.String `tfsdk:"path"`
+	Policy types.String `tfsdk:"policy"`
+}
+
+func (d *policyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p, ok := req.ProviderData.(*taclProvider)
+	if !ok {
+		return
+	}
+	d.httpClient = p.dsHTTPClient
+	d.endpoint = p.endpoint
+	d.logMasks = p.logMasks
+}
+
+func (d *policyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy"
+}
+
+func (d *policyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the full policy document TACL compiles from its ACLs, groups, hosts, tagOwners, etc. " +
+			"Handy for diffing against a checked-in golden file in a `check` block.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Always 'policy'.",
+				Computed:    true,
+			},
+			"path": schema.StringAttribute{
+				Description: "Path of the full-policy endpoint on the TACL server. Defaults to '/policy'.",
+				Optional:    true,
+			},
+			"policy": schema.StringAttribute{
+				Description: "The policy document exactly as TACL returned it.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *policyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = maskLogCtx(ctx, d.logMasks)
+	var data policyDSModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	path := "/policy"
+	if !data.Path.IsNull() && data.Path.ValueString() != "" {
+		path = data.Path.ValueString()
+	}
+
+	// always relative to endpoint, even if the configured path lacks a leading "/"
+	getURL := fmt.Sprintf("%s/%s", d.endpoint, strings.TrimLeft(path, "/"))
+	tflog.Debug(ctx, "Reading full policy from TACL", map[string]interface{}{"url": getURL})
+
+	body, err := doSingleObjectReq(ctx, d.httpClient, http.MethodGet, getURL, nil)
+	if err != nil {
+		if isNotFound(err) {
+			resp.Diagnostics.AddError("Policy endpoint not found",
+				fmt.Sprintf("The TACL server does not expose GET %s.", path))
+			return
+		}
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read policy error", err)
+		return
+	}
+
+	data.ID = types.StringValue("policy")
+	data.Policy = types.StringValue(string(body))
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tailscale/hujson"
+)
+
+// Ensure interface compliance
+var (
+	_ resource.Resource                = &policyResource{}
+	_ resource.ResourceWithConfigure   = &policyResource{}
+	_ resource.ResourceWithImportState = &policyResource{}
+)
+
+// NewPolicyResource => "tacl_policy" resource
+func NewPolicyResource() resource.Resource {
+	return &policyResource{}
+}
+
+// policyResource => the whole tailnet policy file as one document, for teams
+// that keep their HuJSON in git rather than using the granular resources.
+type policyResource struct {
+	httpClient *http.Client
+	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
+}
+
+type policyResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Document types.String `tfsdk:"document"`
+}
+
+func (r *policyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p, ok := req.ProviderData.(*taclProvider)
+	if !ok {
+		return
+	}
+	r.httpClient = p.httpClient
+	r.endpoint = p.endpoint
+	r.logMasks = p.logMasks
+}
+
+func (r *policyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy"
+}
+
+func (r *policyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the entire tailnet policy file as a single HuJSON document via TACL's /policy. " +
+			"Don't combine it with the granular resources (tacl_acl, tacl_group, ...) for the same tailnet; they'd overwrite each other.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Always 'policy'.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"document": schema.StringAttribute{
+				Description: "The full policy file as HuJSON (comments and trailing commas allowed), e.g. `file(\"policy.hujson\")`. " +
+					"Compared semantically: formatting, comments and key order don't cause a diff.",
+				Required: true,
+				Validators: []validator.String{
+					hujsonValidator{},
+				},
+			},
+		},
+	}
+}
+
+// Create => PUT /policy (the policy always exists; creating means taking it over)
+func (r *policyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var plan policyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.putPolicy(ctx, plan.Document.ValueString()); err != nil {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Create policy error", err)
+		return
+	}
+
+	plan.ID = types.StringValue("policy")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read => GET /policy, keeping the configured text if it means the same thing
+func (r *policyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var state policyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	getURL := fmt.Sprintf("%s/policy", r.endpoint)
+	tflog.Debug(ctx, "Reading full policy from TACL", map[string]interface{}{"url": getURL})
+
+	body, err := doPolicyRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
+	if err != nil {
+		if isNotFound(err) {
+			resp.Diagnostics.AddError("Policy endpoint not found",
+				"The TACL server does not expose GET /policy, so tacl_policy can't be used with it.")
+			return
+		}
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read policy error", err)
+		return
+	}
+
+	state.ID = types.StringValue("policy")
+	state.Document = policyDocumentOrPrior(string(body), state.Document)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update => PUT /policy
+func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var plan policyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.putPolicy(ctx, plan.Document.ValueString()); err != nil {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Update policy error", err)
+		return
+	}
+
+	plan.ID = types.StringValue("policy")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete => only forgets the policy. There's no "no policy" state to go back
+// to, and writing an empty one would cut off every device in the tailnet.
+func (r *policyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	resp.Diagnostics.AddWarning("Policy left in place",
+		"tacl_policy was removed from state, but the tailnet policy in TACL was not changed.")
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *policyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), "policy")...)
+}
+
+// putPolicy => PUT /policy with the document as-is, so comments survive
+func (r *policyResource) putPolicy(ctx context.Context, document string) error {
+	putURL := fmt.Sprintf("%s/policy", r.endpoint)
+	tflog.Debug(ctx, "Writing full policy to TACL", map[string]interface{}{
+		"url":   putURL,
+		"bytes": len(document),
+	})
+	_, err := doPolicyRequest(ctx, r.httpClient, http.MethodPut, putURL, []byte(document))
+	return err
+}
+
+// policyDocumentOrPrior => server's document, unless prior is semantically
+// the same policy (TACL may return it reformatted or without comments).
+func policyDocumentOrPrior(server string, prior types.String) types.String {
+	if !prior.IsNull() && !prior.IsUnknown() && policyDocumentsEqual(server, prior.ValueString()) {
+		return prior
+	}
+	return types.StringValue(server)
+}
+
+// policyDocumentsEqual => a and b decode to the same value once comments and
+// trailing commas are stripped. Unparseable documents are only equal byte-for-byte.
+func policyDocumentsEqual(a, b string) bool {
+	va, errA := decodeHuJSON(a)
+	vb, errB := decodeHuJSON(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// decodeHuJSON => HuJSON text => plain Go value
+func decodeHuJSON(doc string) (interface{}, error) {
+	std, err := hujson.Standardize([]byte(doc))
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(std, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// doPolicyRequest => raw-body request against the full-policy endpoint
+func doPolicyRequest(ctx context.Context, client *http.Client, method, url string, document []byte) ([]byte, error) {
+	var body io.Reader
+	if document != nil {
+		body = bytes.NewReader(document)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if document != nil {
+		req.Header.Set("Content-Type", "application/hujson")
+	}
+	req.Header.Set("Accept", "application/hujson, "+jsonAccept)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request error: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return nil, &NotFoundError{Message: "policy not found"}
+	}
+	if res.StatusCode >= 300 {
+		msg, _ := io.ReadAll(res.Body)
+		return nil, newAPIError(res, msg)
+	}
+
+	return io.ReadAll(res.Body)
+}
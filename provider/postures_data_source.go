@@ -28,6 +28,7 @@ func NewPostureDataSource() datasource.DataSource {
 type postureDataSource struct {
 	httpClient *http.Client
 	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
 }
 
 // postureDSModel => the data source model
@@ -35,6 +36,8 @@ type postureDataSource struct {
 type postureDSModel struct {
 	ID    types.String `tfsdk:"id"`    // user must set this to posture name (or "default")
 	Rules types.List   `tfsdk:"rules"` // read from server
+
+	FailIfMissing types.Bool `tfsdk:"fail_if_missing"`
 }
 
 // Configure => get the provider’s httpClient/endpoint
@@ -46,8 +49,9 @@ func (d *postureDataSource) Configure(ctx context.Context, req datasource.Config
 	if !ok {
 		return
 	}
-	d.httpClient = p.httpClient
+	d.httpClient = p.dsHTTPClient
 	d.endpoint = p.endpoint
+	d.logMasks = p.logMasks
 }
 
 // Metadata => set data source name
@@ -58,8 +62,13 @@ func (d *postureDataSource) Metadata(ctx context.Context, req datasource.Metadat
 // Schema => user sets "id" = name of posture. We'll store "rules".
 func (d *postureDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Data source for reading a posture (named or default). If 'id' is 'default', we read /postures/default. Otherwise, /postures/:name.",
+		Description: "Data source for reading a posture (named or default). If 'id' is 'default', we read /postures/default. Otherwise, /postures/:name. " +
+			"For the default posture, prefer the tacl_default_posture data source.",
 		Attributes: map[string]schema.Attribute{
+			"fail_if_missing": schema.BoolAttribute{
+				Description: "If true, a lookup that finds nothing is an error. Defaults to false: a warning, with attributes left null.",
+				Optional:    true,
+			},
 			"id": schema.StringAttribute{
 				Description: "Either a named posture (e.g. 'latestMac') or 'default'.",
 				Required:    true,
@@ -75,6 +84,7 @@ func (d *postureDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 
 // Read => fetch posture by name or default
 func (d *postureDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = maskLogCtx(ctx, d.logMasks)
 	var data postureDSModel
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -98,22 +108,21 @@ func (d *postureDataSource) Read(ctx context.Context, req datasource.ReadRequest
 
 	respBody, err := doDSHTTPRequest(ctx, d.httpClient, http.MethodGet, getURL, nil)
 	if err != nil {
-		if IsNotFound(err) {
-			// no posture => do nothing
+		if isNotFound(err) {
+			addDSMissingDiagnostic(&resp.Diagnostics, data.FailIfMissing, "Posture", fmt.Sprintf("named %q", name))
 			return
 		}
-		resp.Diagnostics.AddError("Error reading posture data source", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Error reading posture data source", err)
 		return
 	}
 
 	if name == "default" {
-		// Expect shape: { "defaultSourcePosture": [...] }
-		var fetched map[string][]string
-		if e := json.Unmarshal(respBody, &fetched); e != nil {
+		// Prefer tacl_default_posture; kept for existing configs
+		rules, e := decodeDefaultPosture(respBody)
+		if e != nil {
 			resp.Diagnostics.AddError("JSON parse error", e.Error())
 			return
 		}
-		rules := fetched["defaultSourcePosture"]
 		data.Rules, _ = toStringListValue(ctx, rules)
 	} else {
 		// Normal posture => { "name":"...","rules":[] }
@@ -160,7 +169,7 @@ func doDSHTTPRequest(ctx context.Context, client *http.Client, method, url strin
 	}
 	if r.StatusCode >= 300 {
 		respB, _ := io.ReadAll(r.Body)
-		return nil, fmt.Errorf("TACL returned HTTP %d: %s", r.StatusCode, string(respB))
+		return nil, newAPIError(r, respB)
 	}
 
 	return io.ReadAll(r.Body)
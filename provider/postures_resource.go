@@ -8,16 +8,20 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-// Ensure postureResource implements Resource/WithConfigure
+// Ensure postureResource implements Resource/WithConfigure/WithValidateConfig
 var (
-	_ resource.Resource              = &postureResource{}
-	_ resource.ResourceWithConfigure = &postureResource{}
+	_ resource.Resource                   = &postureResource{}
+	_ resource.ResourceWithConfigure      = &postureResource{}
+	_ resource.ResourceWithValidateConfig = &postureResource{}
 )
 
 // NewPostureResource => constructor
@@ -28,6 +32,7 @@ func NewPostureResource() resource.Resource {
 type postureResource struct {
 	httpClient *http.Client
 	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
 }
 
 // postureResourceModel => name + rules
@@ -36,22 +41,33 @@ type postureResourceModel struct {
 	ID    types.String `tfsdk:"id"`
 	Name  types.String `tfsdk:"name"`
 	Rules types.List   `tfsdk:"rules"` // list of strings
+
+	Description types.String `tfsdk:"description"` // kept as configured if TACL drops it
 }
 
 // -----------------------------------------------------------------------------
 // TACL JSON shapes
 // -----------------------------------------------------------------------------
 
-// postureCreatePayload => for POST /postures => { "name":"...", "rules":[] }
+// postureCreatePayload => for POST /postures => { "name":"...", "rules":[], "description":"..." }
 type postureCreatePayload struct {
-	Name  string   `json:"name"`
-	Rules []string `json:"rules"`
+	Name        string   `json:"name"`
+	Rules       []string `json:"rules"`
+	Description string   `json:"description,omitempty"` // not every TACL version stores it
 }
 
-// postureUpdatePayload => for PUT /postures => { "name":"...", "rules":[] } (same shape)
+// postureUpdatePayload => for PUT /postures => same shape as create
 type postureUpdatePayload struct {
-	Name  string   `json:"name"`
-	Rules []string `json:"rules"`
+	Name        string   `json:"name"`
+	Rules       []string `json:"rules"`
+	Description string   `json:"description,omitempty"`
+}
+
+// postureResponse => server's shape for a named posture
+type postureResponse struct {
+	Name        string   `json:"name"`
+	Rules       []string `json:"rules"`
+	Description string   `json:"description,omitempty"`
 }
 
 // postureDeletePayload => for DELETE /postures => { "name":"..." }
@@ -64,6 +80,15 @@ type defaultPosturePayload struct {
 	DefaultSourcePosture []string `json:"defaultSourcePosture"`
 }
 
+// decodeDefaultPosture => the rules from a GET /postures/default body
+func decodeDefaultPosture(body []byte) ([]string, error) {
+	var fetched defaultPosturePayload
+	if err := json.Unmarshal(body, &fetched); err != nil {
+		return nil, err
+	}
+	return fetched.DefaultSourcePosture, nil
+}
+
 // -----------------------------------------------------------------------------
 // Configure/Metadata/Schema
 // -----------------------------------------------------------------------------
@@ -78,6 +103,7 @@ func (r *postureResource) Configure(ctx context.Context, req resource.ConfigureR
 	}
 	r.httpClient = p.httpClient
 	r.endpoint = p.endpoint
+	r.logMasks = p.logMasks
 }
 
 func (r *postureResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -88,31 +114,55 @@ func (r *postureResource) Metadata(ctx context.Context, req resource.MetadataReq
 // "ID" is a computed field storing the posture's name
 func (r *postureResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages a single posture (named or default). If 'name' = 'default', we manage the default posture at /postures/default.",
+		Description: "Manages a single posture (named or default). If 'name' = 'default', we manage the default posture at /postures/default (deprecated: use tacl_default_posture).",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Same as 'name'.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
-				Description: "Unique name of posture (or 'default').",
-				Required:    true,
+				Description: "Unique name of posture (or 'default'). Changing it replaces the posture, " +
+					"since the name decides which endpoint (/postures or /postures/default) it lives at.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"rules": schema.ListAttribute{
 				Description: "List of posture rules (strings).",
 				Required:    true,
 				ElementType: types.StringType,
 			},
+			"description": schema.StringAttribute{
+				Description: "Optional note on why the posture exists. Sent to TACL for named postures; if the server doesn't " +
+					"store it (or name = 'default'), the configured value is kept in state.",
+				Optional: true,
+			},
 		},
 	}
 }
 
+// ValidateConfig => name = "default" still works, but tacl_default_posture is
+// the explicit way to manage it.
+func (r *postureResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var name types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	if name.ValueString() == "default" {
+		resp.Diagnostics.AddAttributeWarning(path.Root("name"), "Deprecated default posture",
+			"Managing the default posture with tacl_posture name = \"default\" is deprecated; use the tacl_default_posture resource instead.")
+	}
+}
+
 // -----------------------------------------------------------------------------
 // Create => if name=default => PUT /postures/default
 //           else => POST /postures
 // -----------------------------------------------------------------------------
 
 func (r *postureResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var plan postureResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -123,7 +173,7 @@ func (r *postureResource) Create(ctx context.Context, req resource.CreateRequest
 	name := plan.Name.ValueString()
 	rules, err := listToGoStrings(ctx, plan.Rules)
 	if err != nil {
-		resp.Diagnostics.AddError("Rules conversion error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Rules conversion error", err)
 		return
 	}
 
@@ -134,12 +184,12 @@ func (r *postureResource) Create(ctx context.Context, req resource.CreateRequest
 
 		tflog.Debug(ctx, "Creating default posture via TACL", map[string]interface{}{
 			"url":     putURL,
-			"payload": payload,
+			"payload": redactForLog(payload),
 		})
 
 		_, err := doPostureRequest(ctx, r.httpClient, http.MethodPut, putURL, payload)
 		if err != nil {
-			resp.Diagnostics.AddError("Create default posture error", err.Error())
+			addAPIErrorDiagnostic(&resp.Diagnostics, "Create default posture error", err)
 			return
 		}
 		plan.ID = plan.Name // store "default" in ID
@@ -147,31 +197,30 @@ func (r *postureResource) Create(ctx context.Context, req resource.CreateRequest
 		// => POST /postures => { "name":"...", "rules":[] }
 		postURL := fmt.Sprintf("%s/postures", r.endpoint)
 		payload := postureCreatePayload{
-			Name:  name,
-			Rules: rules,
+			Name:        name,
+			Rules:       rules,
+			Description: plan.Description.ValueString(),
 		}
 		tflog.Debug(ctx, "Creating named posture via TACL", map[string]interface{}{
 			"url":     postURL,
-			"payload": payload,
+			"payload": redactForLog(payload),
 		})
 
 		respBody, err := doPostureRequest(ctx, r.httpClient, http.MethodPost, postURL, payload)
 		if err != nil {
-			resp.Diagnostics.AddError("Create posture error", err.Error())
+			addAPIErrorDiagnostic(&resp.Diagnostics, "Create posture error", err)
 			return
 		}
 
 		// Typically server responds with { "name":"...", "rules":[...] }
-		var created struct {
-			Name  string   `json:"name"`
-			Rules []string `json:"rules"`
-		}
+		var created postureResponse
 		if e := json.Unmarshal(respBody, &created); e != nil {
 			resp.Diagnostics.AddError("Error parsing create response", e.Error())
 			return
 		}
 
 		plan.ID = types.StringValue(created.Name)
+		plan.Description = commentOrPrior(created.Description, plan.Description)
 	}
 
 	diags = resp.State.Set(ctx, &plan)
@@ -184,6 +233,7 @@ func (r *postureResource) Create(ctx context.Context, req resource.CreateRequest
 // -----------------------------------------------------------------------------
 
 func (r *postureResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var state postureResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -206,19 +256,18 @@ func (r *postureResource) Read(ctx context.Context, req resource.ReadRequest, re
 		})
 		body, err := doPostureRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
 		if err != nil {
-			if IsNotFound(err) {
+			if isNotFound(err) {
 				resp.State.RemoveResource(ctx)
 				return
 			}
-			resp.Diagnostics.AddError("Read default posture error", err.Error())
+			addAPIErrorDiagnostic(&resp.Diagnostics, "Read default posture error", err)
 			return
 		}
-		var fetched map[string][]string // e.g. { "defaultSourcePosture": [...] }
-		if e := json.Unmarshal(body, &fetched); e != nil {
+		rules, e := decodeDefaultPosture(body)
+		if e != nil {
 			resp.Diagnostics.AddError("Parse default posture error", e.Error())
 			return
 		}
-		rules := fetched["defaultSourcePosture"]
 		state.Rules, _ = goStringsToList(rules)
 
 	} else {
@@ -230,22 +279,20 @@ func (r *postureResource) Read(ctx context.Context, req resource.ReadRequest, re
 		})
 		body, err := doPostureRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
 		if err != nil {
-			if IsNotFound(err) {
+			if isNotFound(err) {
 				resp.State.RemoveResource(ctx)
 				return
 			}
-			resp.Diagnostics.AddError("Read named posture error", err.Error())
+			addAPIErrorDiagnostic(&resp.Diagnostics, "Read named posture error", err)
 			return
 		}
-		var fetched struct {
-			Name  string   `json:"name"`
-			Rules []string `json:"rules"`
-		}
+		var fetched postureResponse
 		if e := json.Unmarshal(body, &fetched); e != nil {
 			resp.Diagnostics.AddError("Parse named posture error", e.Error())
 			return
 		}
 		state.Rules, _ = goStringsToList(fetched.Rules)
+		state.Description = commentOrPrior(fetched.Description, state.Description)
 	}
 
 	diags = resp.State.Set(ctx, &state)
@@ -258,6 +305,7 @@ func (r *postureResource) Read(ctx context.Context, req resource.ReadRequest, re
 // -----------------------------------------------------------------------------
 
 func (r *postureResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var oldState postureResourceModel
 	diags := req.State.Get(ctx, &oldState)
 	resp.Diagnostics.Append(diags...)
@@ -275,7 +323,7 @@ func (r *postureResource) Update(ctx context.Context, req resource.UpdateRequest
 	name := plan.Name.ValueString()
 	rules, err := listToGoStrings(ctx, plan.Rules)
 	if err != nil {
-		resp.Diagnostics.AddError("Rules conversion error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Rules conversion error", err)
 		return
 	}
 
@@ -287,15 +335,15 @@ func (r *postureResource) Update(ctx context.Context, req resource.UpdateRequest
 		}
 		tflog.Debug(ctx, "Updating default posture", map[string]interface{}{
 			"url":     putURL,
-			"payload": payload,
+			"payload": redactForLog(payload),
 		})
 		_, err := doPostureRequest(ctx, r.httpClient, http.MethodPut, putURL, payload)
 		if err != nil {
-			if IsNotFound(err) {
+			if isNotFound(err) {
 				resp.State.RemoveResource(ctx)
 				return
 			}
-			resp.Diagnostics.AddError("Update default posture error", err.Error())
+			addAPIErrorDiagnostic(&resp.Diagnostics, "Update default posture error", err)
 			return
 		}
 		plan.ID = plan.Name
@@ -304,32 +352,31 @@ func (r *postureResource) Update(ctx context.Context, req resource.UpdateRequest
 		// PUT /postures => { "name":"...", "rules":[] }
 		putURL := fmt.Sprintf("%s/postures", r.endpoint)
 		payload := postureUpdatePayload{
-			Name:  name,
-			Rules: rules,
+			Name:        name,
+			Rules:       rules,
+			Description: plan.Description.ValueString(),
 		}
 		tflog.Debug(ctx, "Updating named posture", map[string]interface{}{
 			"url":     putURL,
-			"payload": payload,
+			"payload": redactForLog(payload),
 		})
 		body, err := doPostureRequest(ctx, r.httpClient, http.MethodPut, putURL, payload)
 		if err != nil {
-			if IsNotFound(err) {
+			if isNotFound(err) {
 				resp.State.RemoveResource(ctx)
 				return
 			}
-			resp.Diagnostics.AddError("Update named posture error", err.Error())
+			addAPIErrorDiagnostic(&resp.Diagnostics, "Update named posture error", err)
 			return
 		}
 		// We might parse the response if needed, but presumably the server returns { "name":"...", "rules":[] }
-		var updated struct {
-			Name  string   `json:"name"`
-			Rules []string `json:"rules"`
-		}
+		var updated postureResponse
 		if e := json.Unmarshal(body, &updated); e != nil {
 			resp.Diagnostics.AddError("Parse update response error", e.Error())
 			return
 		}
 		plan.ID = types.StringValue(updated.Name)
+		plan.Description = commentOrPrior(updated.Description, plan.Description)
 	}
 
 	diags = resp.State.Set(ctx, &plan)
@@ -342,6 +389,7 @@ func (r *postureResource) Update(ctx context.Context, req resource.UpdateRequest
 // -----------------------------------------------------------------------------
 
 func (r *postureResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data postureResourceModel
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -362,13 +410,9 @@ func (r *postureResource) Delete(ctx context.Context, req resource.DeleteRequest
 			"url": delURL,
 		})
 		_, err := doPostureRequest(ctx, r.httpClient, http.MethodDelete, delURL, nil)
-		if err != nil {
-			if IsNotFound(err) {
-				// already gone
-			} else {
-				resp.Diagnostics.AddError("Delete default posture error", err.Error())
-				return
-			}
+		if err != nil && !isNotFound(err) {
+			addAPIErrorDiagnostic(&resp.Diagnostics, "Delete default posture error", err)
+			return
 		}
 		resp.State.RemoveResource(ctx)
 	} else {
@@ -380,13 +424,9 @@ func (r *postureResource) Delete(ctx context.Context, req resource.DeleteRequest
 		})
 		payload := postureDeletePayload{Name: name}
 		_, err := doPostureRequest(ctx, r.httpClient, http.MethodDelete, delURL, payload)
-		if err != nil {
-			if IsNotFound(err) {
-				// already gone
-			} else {
-				resp.Diagnostics.AddError("Delete named posture error", err.Error())
-				return
-			}
+		if err != nil && !isNotFound(err) {
+			addAPIErrorDiagnostic(&resp.Diagnostics, "Delete named posture error", err)
+			return
 		}
 		resp.State.RemoveResource(ctx)
 	}
@@ -424,7 +464,7 @@ func doPostureRequest(ctx context.Context, client *http.Client, method, url stri
 	}
 	if resp.StatusCode >= 300 {
 		msg, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("TACL returned %d: %s", resp.StatusCode, string(msg))
+		return nil, newAPIError(resp, msg)
 	}
 
 	return io.ReadAll(resp.Body)
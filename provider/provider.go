@@ -5,37 +5,88 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
+	"tailscale.com/tsnet"
 )
 
 // taclProviderModel defines user-facing configuration fields.
 type taclProviderModel struct {
 	Endpoint     types.String `tfsdk:"endpoint"` // required
+	PathPrefix   types.String `tfsdk:"path_prefix"`
 	ClientID     types.String `tfsdk:"client_id"`
 	ClientSecret types.String `tfsdk:"client_secret"`
 	TailnetName  types.String `tfsdk:"tailnet_name"`
 	Tags         types.String `tfsdk:"tags"`
 	Ephemeral    types.Bool   `tfsdk:"ephemeral"`
+	Flavor       types.String `tfsdk:"flavor"`
+
+	ValidateOnPlan types.Bool  `tfsdk:"validate_on_plan"`
+	NormalizeLists types.Bool  `tfsdk:"normalize_lists"`
+	MaxRetries     types.Int64 `tfsdk:"max_retries"`
+	NoRetryJitter  types.Bool  `tfsdk:"disable_retry_jitter"`
+	Debug          types.Bool  `tfsdk:"debug"`
+	DebugHTTP      types.Bool  `tfsdk:"debug_http"`
+
+	DetectErrorBodies types.Bool `tfsdk:"detect_error_bodies"`
+
+	PreserveUnknownFields types.Bool `tfsdk:"preserve_unknown_fields"`
+
+	Headers types.Map `tfsdk:"headers"`
+
+	ReadAfterWriteAttempts types.Int64  `tfsdk:"read_after_write_attempts"`
+	ReadAfterWriteInterval types.String `tfsdk:"read_after_write_interval"`
+
+	MaxIdleConnsPerHost types.Int64  `tfsdk:"max_idle_conns_per_host"`
+	IdleConnTimeout     types.String `tfsdk:"idle_conn_timeout"`
+
+	TLSHandshakeTimeout   types.String `tfsdk:"tls_handshake_timeout"`
+	DisableCompression    types.Bool   `tfsdk:"disable_compression"`
+	ExpectContinueTimeout types.String `tfsdk:"expect_continue_timeout"`
 }
 
-// taclProvider holds state needed after configuration.
+// taclProvider holds state needed after configuration. It's handed to every
+// resource and data source as ProviderData, and Terraform runs those in
+// parallel: fields are written only in Configure and read-only afterwards.
+// Anything that changes later (like the data source read cache) must guard
+// itself with a mutex.
 type taclProvider struct {
 	httpClient    *http.Client
+	dsHTTPClient  *http.Client // httpClient plus the shared data source read cache
 	endpoint      string
 	tailnetName   string
 	ephemeralMode bool
 	tags          string
+	flavor        string         // flavorTailscale or flavorHeadscale
+	tsNode        *ephemeralNode // ephemeral tailnet node, if ephemeral = true
+	logMasks      []string       // client_secret and header values, see maskLogCtx
+
+	validateOnPlan bool // dry-run ACLs against TACL during plan
+	normalizeLists bool // trim/dedupe ACL and SSH src/dst/users before sending
+	readAfterWrite readAfterWrite
+	debug          bool // keep raw server responses in raw_json
+	noRetryJitter  bool // disable_retry_jitter, for retries outside retryTransport
+
+	preserveUnknownFields bool // merge unmodeled server fields into ACL/SSH PUTs
 }
 
 // Compile-time check that taclProvider implements provider.Provider.
-var _ provider.Provider = (*taclProvider)(nil)
+var (
+	_ provider.Provider              = (*taclProvider)(nil)
+	_ provider.ProviderWithFunctions = (*taclProvider)(nil)
+)
 
 // New returns a single instance of the taclProvider.
 func New() provider.Provider {
@@ -53,8 +104,128 @@ func (p *taclProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 		Description: "Provider for TACL (Tailscale ACL).",
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				Description: "TACL server URL (e.g. http://localhost:8080).",
+				Description: "TACL server URL (e.g. http://localhost:8080). Must be an http:// or https:// URL with a host; a trailing slash is ignored. The provider checks the host resolves (as a MagicDNS peer first when ephemeral = true) and that it can reach the endpoint when configured, and warns if not.",
 				Required:    true,
+				Validators: []validator.String{
+					endpointValidator{},
+				},
+			},
+			"path_prefix": schema.StringAttribute{
+				Description: "Path TACL is mounted under when it sits behind a shared reverse proxy, e.g. '/tacl'. " +
+					"Joined onto `endpoint`'s path, so requests go to http://host/tacl/acls. Leading/trailing slashes don't matter.",
+				Optional: true,
+			},
+			"client_id": schema.StringAttribute{
+				Description: "OAuth client ID for ephemeral Tailscale authentication (optional).",
+				Optional:    true,
+			},
+			"client_secret": schema.StringAttribute{
+				Description: "OAuth client secret for ephemeral Tailscale authentication (optional).",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"tailnet_name": schema.StringAttribute{
+				Description: "Tailnet name (e.g. mycorp.ts.net). When set, it's sent as a `Tailnet` header on every request so a multi-tenant TACL can scope requests to this tailnet.",
+				Optional:    true,
+			},
+			"tags": schema.StringAttribute{
+				Description: "Comma-separated tags for the ephemeral Tailscale node, e.g. 'tag:terraform'. Required when ephemeral = true.",
+				Optional:    true,
+			},
+			"flavor": schema.StringAttribute{
+				Description: "Backend TACL manages: 'tailscale' (default) or 'headscale'. With 'headscale', objects whose " +
+					"shape differs (the DERPMap) are sent in Headscale's format.",
+				Optional: true,
+			},
+			"validate_on_plan": schema.BoolAttribute{
+				Description: "If true, tacl_acl changes are sent to TACL's POST /acls/validate during plan so server-side " +
+					"rejections show up before apply. A no-op (with a warning) if the server lacks that endpoint. Defaults to false.",
+				Optional: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "How many times a request is retried after a connection error or a 429/502/503/504 from TACL, " +
+					"with jittered exponential backoff. Non-idempotent requests are only retried when the server can't have processed them. " +
+					"Defaults to 3; 0 disables retries.",
+				Optional: true,
+			},
+			"disable_retry_jitter": schema.BoolAttribute{
+				Description: "If true, retries wait the exact exponential backoff instead of a random time between 0 and it. " +
+					"Meant for deterministic tests; jitter keeps many failing resources from retrying in lockstep. Defaults to false.",
+				Optional: true,
+			},
+			"headers": schema.MapAttribute{
+				Description: "Extra HTTP headers sent on every request to TACL, e.g. Cloudflare Access's " +
+					"`CF-Access-Client-Id`/`CF-Access-Client-Secret`. `tailnet_name` takes precedence over a `Tailnet` entry here. " +
+					"An `Accept` entry replaces the default `application/json`.",
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
+			"max_idle_conns_per_host": schema.Int64Attribute{
+				Description: "Idle connections kept open to TACL for reuse. Raise it when running terraform with a higher -parallelism. Defaults to 10.",
+				Optional:    true,
+			},
+			"idle_conn_timeout": schema.StringAttribute{
+				Description: "How long an idle connection to TACL is kept before closing, as a Go duration (e.g. '90s', '2m'). Defaults to 90s.",
+				Optional:    true,
+			},
+			"detect_error_bodies": schema.BoolAttribute{
+				Description: "If true, a 2xx response whose body is a JSON error object (a non-empty `error` member, or " +
+					"`\"success\": false`) is treated as a failed request instead of being decoded as the expected object. " +
+					"For proxies that answer 200 with an error body. Defaults to false.",
+				Optional: true,
+			},
+			"disable_compression": schema.BoolAttribute{
+				Description: "If true, the provider doesn't ask TACL for gzip-compressed responses. By default it sends " +
+					"`Accept-Encoding: gzip` and decodes compressed responses transparently, which speeds up large ACL lists " +
+					"and policy documents over slow links. Defaults to false.",
+				Optional: true,
+			},
+			"tls_handshake_timeout": schema.StringAttribute{
+				Description: "How long to wait for the TLS handshake with TACL, as a Go duration (e.g. '30s'). " +
+					"Raise it on high-latency links. Defaults to 10s; '0s' means no limit.",
+				Optional: true,
+			},
+			"expect_continue_timeout": schema.StringAttribute{
+				Description: "How long to wait for TACL's '100 Continue' before sending a request body when the request asks for one, " +
+					"as a Go duration (e.g. '3s'). Defaults to 1s; '0s' sends the body immediately.",
+				Optional: true,
+			},
+			"normalize_lists": schema.BoolAttribute{
+				Description: "If true, tacl_acl and tacl_ssh trim whitespace and drop duplicate entries from src/dst/users " +
+					"before sending them to TACL. Defaults to false: lists are sent exactly as written.",
+				Optional: true,
+			},
+			"read_after_write_attempts": schema.Int64Attribute{
+				Description: "After creating a tacl_acl, tacl_ssh or tacl_nodeattr, GET it back up to this many times until it's " +
+					"readable, for TACL deployments with replication lag. Defaults to 0: no confirmation read.",
+				Optional: true,
+			},
+			"read_after_write_interval": schema.StringAttribute{
+				Description: "Wait between `read_after_write_attempts`, as a Go duration (e.g. '500ms'). Defaults to 1s.",
+				Optional:    true,
+			},
+			"debug": schema.BoolAttribute{
+				Description: "If true, tacl_acl, tacl_ssh and tacl_derpmap keep the last response body TACL returned for them " +
+					"in a computed `raw_json` attribute, to help debug state that doesn't match expectations. Defaults to false.",
+				Optional: true,
+			},
+			"debug_http": schema.BoolAttribute{
+				Description: "If true, every HTTP request to TACL (method, URL, headers, body) and its response (status, headers, body) " +
+					"is logged at DEBUG level (TF_LOG=DEBUG), including retries. Authorization, cookies and everything set via `headers` " +
+					"are redacted, as are secret-looking JSON fields. Defaults to false.",
+				Optional: true,
+			},
+			"preserve_unknown_fields": schema.BoolAttribute{
+				Description: "If true, updates to tacl_acl and tacl_ssh first GET the current object and carry over any fields " +
+					"the provider doesn't model (e.g. from a newer TACL), instead of dropping them. Costs one extra GET per update. " +
+					"tacl_settings always does this. Defaults to false.",
+				Optional: true,
+			},
+			"ephemeral": schema.BoolAttribute{
+				Description: "If true, the provider joins the tailnet as an ephemeral node (using client_id/client_secret to mint a key " +
+					"tagged with `tags`) and reaches `endpoint` over the tailnet, so it can be a MagicDNS name. Defaults to false.",
+				Optional: true,
 			},
 		},
 	}
@@ -69,33 +240,240 @@ func (p *taclProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
-	// Required: endpoint
-	p.endpoint = config.Endpoint.ValueString()
+	// Required: endpoint. Resources append "/groups" etc., so a trailing
+	// slash would produce "//groups" and confusing 404s.
+	p.endpoint = strings.TrimRight(config.Endpoint.ValueString(), "/")
+	if !config.Endpoint.IsUnknown() {
+		if err := checkEndpointURL(p.endpoint); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("endpoint"), "Invalid endpoint", err.Error())
+			return
+		}
+		endpoint, err := joinPathPrefix(p.endpoint, config.PathPrefix.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("path_prefix"), "Invalid path_prefix", err.Error())
+			return
+		}
+		p.endpoint = endpoint
+	}
 	// Optional fields
 	p.tailnetName = config.TailnetName.ValueString()
 	p.ephemeralMode = !config.Ephemeral.IsNull() && config.Ephemeral.ValueBool()
 	p.tags = config.Tags.ValueString()
+	p.validateOnPlan = !config.ValidateOnPlan.IsNull() && config.ValidateOnPlan.ValueBool()
+	p.normalizeLists = !config.NormalizeLists.IsNull() && config.NormalizeLists.ValueBool()
+	p.debug = !config.Debug.IsNull() && config.Debug.ValueBool()
+	p.preserveUnknownFields = config.PreserveUnknownFields.ValueBool()
+
+	p.flavor = flavorTailscale
+	if !config.Flavor.IsNull() && config.Flavor.ValueString() != "" {
+		p.flavor = config.Flavor.ValueString()
+	}
+	if p.flavor != flavorTailscale && p.flavor != flavorHeadscale {
+		resp.Diagnostics.AddAttributeError(path.Root("flavor"), "Invalid flavor",
+			fmt.Sprintf("flavor must be %q or %q, got %q.", flavorTailscale, flavorHeadscale, p.flavor))
+		return
+	}
+
+	p.readAfterWrite = readAfterWrite{interval: time.Second}
+	if !config.ReadAfterWriteAttempts.IsNull() {
+		p.readAfterWrite.attempts = int(config.ReadAfterWriteAttempts.ValueInt64())
+		if p.readAfterWrite.attempts < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("read_after_write_attempts"), "Invalid read_after_write_attempts",
+				fmt.Sprintf("read_after_write_attempts must be 0 or greater, got %d.", p.readAfterWrite.attempts))
+			return
+		}
+	}
+	if !config.ReadAfterWriteInterval.IsNull() && config.ReadAfterWriteInterval.ValueString() != "" {
+		d, err := time.ParseDuration(config.ReadAfterWriteInterval.ValueString())
+		if err != nil || d < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("read_after_write_interval"), "Invalid read_after_write_interval",
+				fmt.Sprintf("read_after_write_interval must be a non-negative Go duration like '500ms', got %q.", config.ReadAfterWriteInterval.ValueString()))
+			return
+		}
+		p.readAfterWrite.interval = d
+	}
 
 	clientID := config.ClientID.ValueString()
 	clientSecret := config.ClientSecret.ValueString()
 
+	// Never let the secret reach log output, whatever field it ends up in.
+	// p.logMasks is handed to every resource and data source for the same.
+	p.logMasks = nil
+	if clientSecret != "" {
+		p.logMasks = append(p.logMasks, clientSecret)
+	}
+	ctx = maskLogCtx(ctx, p.logMasks)
+
+	pool := poolOptions{
+		maxIdleConnsPerHost:   defaultMaxIdleConnsPerHost,
+		idleConnTimeout:       defaultIdleConnTimeout,
+		tlsHandshakeTimeout:   defaultTLSHandshakeTimeout,
+		expectContinueTimeout: defaultExpectContinueTimeout,
+		disableCompression:    config.DisableCompression.ValueBool(),
+	}
+	if !config.MaxIdleConnsPerHost.IsNull() {
+		pool.maxIdleConnsPerHost = int(config.MaxIdleConnsPerHost.ValueInt64())
+		if pool.maxIdleConnsPerHost < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_idle_conns_per_host"), "Invalid max_idle_conns_per_host",
+				fmt.Sprintf("max_idle_conns_per_host must be at least 1, got %d.", pool.maxIdleConnsPerHost))
+			return
+		}
+	}
+	if !config.IdleConnTimeout.IsNull() && config.IdleConnTimeout.ValueString() != "" {
+		d, err := time.ParseDuration(config.IdleConnTimeout.ValueString())
+		if err != nil || d < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("idle_conn_timeout"), "Invalid idle_conn_timeout",
+				fmt.Sprintf("idle_conn_timeout must be a non-negative Go duration like '90s', got %q.", config.IdleConnTimeout.ValueString()))
+			return
+		}
+		pool.idleConnTimeout = d
+	}
+	if !config.TLSHandshakeTimeout.IsNull() && config.TLSHandshakeTimeout.ValueString() != "" {
+		d, err := time.ParseDuration(config.TLSHandshakeTimeout.ValueString())
+		if err != nil || d < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("tls_handshake_timeout"), "Invalid tls_handshake_timeout",
+				fmt.Sprintf("tls_handshake_timeout must be a non-negative Go duration like '30s', got %q.", config.TLSHandshakeTimeout.ValueString()))
+			return
+		}
+		pool.tlsHandshakeTimeout = d
+	}
+	if !config.ExpectContinueTimeout.IsNull() && config.ExpectContinueTimeout.ValueString() != "" {
+		d, err := time.ParseDuration(config.ExpectContinueTimeout.ValueString())
+		if err != nil || d < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("expect_continue_timeout"), "Invalid expect_continue_timeout",
+				fmt.Sprintf("expect_continue_timeout must be a non-negative Go duration like '3s', got %q.", config.ExpectContinueTimeout.ValueString()))
+			return
+		}
+		pool.expectContinueTimeout = d
+	}
+
+	// Base transport => over the tailnet if ephemeral, otherwise the host
+	// network with a pool tuned for parallel reads
+	var base http.RoundTripper = newBaseTransport(pool)
+	if p.ephemeralMode {
+		tags := splitTags(p.tags)
+		if clientID == "" || clientSecret == "" || len(tags) == 0 {
+			resp.Diagnostics.AddError("Incomplete ephemeral configuration",
+				"ephemeral = true requires client_id, client_secret and tags so the provider can mint a tagged ephemeral auth key.")
+			return
+		}
+		if p.tsNode == nil {
+			node, err := startEphemeralNode(ctx, clientID, clientSecret, p.tailnetName, tags)
+			if err != nil {
+				resp.Diagnostics.AddError("Failed to join tailnet", err.Error())
+				return
+			}
+			p.tsNode = node
+		}
+		// Same pool tuning, but dialing through the tailnet node
+		t := newBaseTransport(pool)
+		t.DialContext = p.tsNode.srv.Dial
+		t.Proxy = nil
+		base = t
+	}
+	base = &gzipTransport{base: base}
+	if config.DebugHTTP.ValueBool() {
+		// `headers` is sensitive as a whole: mask every name set there
+		var extra []string
+		if !config.Headers.IsNull() && !config.Headers.IsUnknown() {
+			for name := range config.Headers.Elements() {
+				extra = append(extra, name)
+			}
+		}
+		base = newDebugHTTPTransport(base, extra)
+	}
+
 	if clientID != "" && clientSecret != "" {
-		// Ephemeral OAuth-based Tailscale auth
-		tflog.Info(ctx, "Using ephemeral OAuth-based Tailscale auth")
+		// OAuth-based Tailscale auth
+		tflog.Info(ctx, "Using OAuth-based Tailscale auth")
 		creds := clientcredentials.Config{
 			ClientID:     clientID,
 			ClientSecret: clientSecret,
 			TokenURL:     "https://login.tailscale.com/api/v2/oauth/token",
 		}
-		p.httpClient = creds.Client(context.Background())
+		p.httpClient = &http.Client{
+			Transport: &oauth2.Transport{
+				Source: creds.TokenSource(context.Background()),
+				Base:   base,
+			},
+		}
 	} else {
 		tflog.Warn(ctx, "No Tailscale auth configured, using default client")
-		p.httpClient = http.DefaultClient
+		p.httpClient = &http.Client{Transport: base}
 	}
 
+	// Ask for JSON so a negotiating proxy doesn't hand back an HTML page;
+	// `headers` can override it
+	headers := map[string]string{"Accept": jsonAccept}
+	if !config.Headers.IsNull() && !config.Headers.IsUnknown() {
+		var extra map[string]string
+		resp.Diagnostics.Append(config.Headers.ElementsAs(ctx, &extra, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for name, value := range extra {
+			if name == "" || strings.ContainsAny(name, " \t\r\n:") {
+				resp.Diagnostics.AddAttributeError(path.Root("headers"), "Invalid header name",
+					fmt.Sprintf("%q is not a valid HTTP header name.", name))
+				return
+			}
+			headers[http.CanonicalHeaderKey(name)] = value
+			if value != "" {
+				p.logMasks = append(p.logMasks, value)
+				ctx = maskLogCtx(ctx, p.logMasks)
+			}
+		}
+	}
+	if p.tailnetName != "" {
+		headers[tailnetHeader] = p.tailnetName
+	}
+	p.httpClient = wrapClient(p.httpClient, headers)
+	// Some TACL versions answer { "data": {...} } instead of the bare object
+	p.httpClient.Transport = &envelopeTransport{
+		base:              p.httpClient.Transport,
+		detectErrorBodies: config.DetectErrorBodies.ValueBool(),
+	}
+
+	if !config.Endpoint.IsUnknown() {
+		// Only warnings: TACL may legitimately come up later in the run
+		var tailnetNode *tsnet.Server
+		if p.ephemeralMode {
+			tailnetNode = p.tsNode.srv
+		}
+		if msg := checkEndpointHost(ctx, p.endpoint, tailnetNode); msg != "" {
+			resp.Diagnostics.AddAttributeWarning(path.Root("endpoint"), "TACL endpoint host not found", msg)
+		} else if err := probeEndpoint(ctx, p.httpClient.Transport, p.endpoint); err != nil {
+			resp.Diagnostics.AddAttributeWarning(path.Root("endpoint"), "TACL endpoint unreachable",
+				fmt.Sprintf("Could not reach %s: %s. Requests to TACL will likely fail.", p.endpoint, err))
+		}
+	}
+
+	maxRetries := int64(defaultMaxRetries)
+	if !config.MaxRetries.IsNull() {
+		maxRetries = config.MaxRetries.ValueInt64()
+	}
+	if maxRetries < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_retries"), "Invalid max_retries",
+			fmt.Sprintf("max_retries must be 0 or greater, got %d.", maxRetries))
+		return
+	}
+	p.httpClient.Transport = &retryTransport{
+		base:       p.httpClient.Transport,
+		maxRetries: int(maxRetries),
+		noJitter:   config.NoRetryJitter.ValueBool(),
+	}
+	p.noRetryJitter = config.NoRetryJitter.ValueBool()
+
+	// Data sources share a short-lived read cache; resource writes invalidate it
+	cache := newResponseCache(dataSourceCacheTTL)
+	dsClient := *p.httpClient
+	dsClient.Transport = &cacheTransport{base: p.httpClient.Transport, cache: cache, serve: true}
+	p.dsHTTPClient = &dsClient
+	p.httpClient.Transport = &cacheTransport{base: p.httpClient.Transport, cache: cache}
+
 	tflog.Debug(ctx, fmt.Sprintf(
-		"Provider configured with endpoint=%s, tailnet=%s, ephemeral=%v",
-		p.endpoint, p.tailnetName, p.ephemeralMode))
+		"Provider configured with endpoint=%s, tailnet=%s, ephemeral=%v, flavor=%s",
+		p.endpoint, p.tailnetName, p.ephemeralMode, p.flavor))
 
 	resp.ResourceData = p
 	resp.DataSourceData = p
@@ -109,11 +487,18 @@ func (p *taclProvider) DataSources(_ context.Context) []func() datasource.DataSo
 		NewAutoApproversDataSource,
 		NewDERPMapDataSource,
 		NewHostsDataSource,
+		NewHostsMapDataSource,
 		NewSettingsDataSource,
 		NewNodeAttrDataSource,
+		NewNodeAttrsDataSource,
 		NewPostureDataSource,
+		NewDefaultPostureDataSource,
 		NewSSHDataSource,
 		NewTagOwnersDataSource,
+		NewHealthDataSource,
+		NewACLValidationDataSource,
+		NewPolicyDataSource,
+		NewTailnetInfoDataSource,
 	}
 }
 
@@ -128,7 +513,24 @@ func (p *taclProvider) Resources(_ context.Context) []func() resource.Resource {
 		NewSettingsResource,
 		NewNodeAttrResource,
 		NewPostureResource,
+		NewDefaultPostureResource,
 		NewSSHResource,
+		NewSSHsResource,
+		NewGrantResource,
+		NewACLTestResource,
 		NewTagOwnersResource,
+		NewTagOwnersMapResource,
+		NewPolicyResource,
+	}
+}
+
+// Functions returns a list of provider-defined function constructors.
+func (p *taclProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewTagFunction,
+		NewHostPortFunction,
+		NewIsCIDRFunction,
+		NewNormalizeCIDRFunction,
+		NewParseACLsFunction,
 	}
 }
@@ -0,0 +1,312 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// gone => what the server lookups below report for a missing object
+const gone = "<gone>"
+
+// namedField => field of /<coll>/<name> on srv
+func namedField(coll, name, field string) func(*fakeTACL) string {
+	return func(srv *fakeTACL) string {
+		obj, ok := srv.named[coll][name]
+		if !ok {
+			return gone
+		}
+		return fmt.Sprint(obj[field])
+	}
+}
+
+// byIDField => field of the only object in the ID-keyed coll on srv
+func byIDField(coll, field string) func(*fakeTACL) string {
+	return func(srv *fakeTACL) string {
+		switch objs := srv.byID[coll]; len(objs) {
+		case 0:
+			return gone
+		case 1:
+			return fmt.Sprint(objs[0][field])
+		default:
+			return fmt.Sprintf("%d objects", len(objs))
+		}
+	}
+}
+
+// singletonField => field of the singleton at path on srv
+func singletonField(path, field string) func(*fakeTACL) string {
+	return func(srv *fakeTACL) string {
+		obj, ok := srv.singleton[path]
+		if !ok {
+			return gone
+		}
+		return fmt.Sprint(obj[field])
+	}
+}
+
+// TestResourceCRUD => every resource through create, read, update, read and
+// delete against the fake TACL: the server holds what was configured, a
+// refresh right after each write leaves state unchanged (no diff on the next
+// plan), and delete removes the object.
+func TestResourceCRUD(t *testing.T) {
+	tests := []struct {
+		name        string
+		newResource func() resource.Resource
+		create      map[string]interface{}
+		update      map[string]interface{}
+		server      func(*fakeTACL) string // what the server holds
+		wantCreate  string
+		wantUpdate  string
+		wantDeleted string // server after delete; defaults to gone
+	}{
+		{
+			name:        "group",
+			newResource: NewGroupResource,
+			create:      map[string]interface{}{"name": "eng", "members": []string{"bob@example.com", "alice@example.com"}},
+			update:      map[string]interface{}{"name": "eng", "members": []string{"carol@example.com"}},
+			server:      namedField("groups", "eng", "members"),
+			wantCreate:  "[alice@example.com bob@example.com]",
+			wantUpdate:  "[carol@example.com]",
+		},
+		{
+			name:        "hosts",
+			newResource: NewHostsResource,
+			create:      map[string]interface{}{"name": "db", "ip": "10.0.0.10"},
+			update:      map[string]interface{}{"name": "db", "ip": "10.0.0.11"},
+			server:      namedField("hosts", "db", "ip"),
+			wantCreate:  "10.0.0.10",
+			wantUpdate:  "10.0.0.11",
+		},
+		{
+			name:        "tag_owners",
+			newResource: NewTagOwnersResource,
+			create:      map[string]interface{}{"name": "tag:web", "owners": []string{"group:eng"}},
+			update:      map[string]interface{}{"name": "tag:web", "owners": []string{"group:eng", "group:ops"}},
+			server:      namedField("tagowners", "tag:web", "owners"),
+			wantCreate:  "[group:eng]",
+			wantUpdate:  "[group:eng group:ops]",
+		},
+		{
+			name:        "tag_owners_map",
+			newResource: NewTagOwnersMapResource,
+			create:      map[string]interface{}{"tag_owners": map[string]interface{}{"tag:web": []string{"group:eng"}}},
+			update:      map[string]interface{}{"tag_owners": map[string]interface{}{"tag:db": []string{"group:ops"}}},
+			server: func(srv *fakeTACL) string {
+				owners := map[string]interface{}{}
+				for name, obj := range srv.named["tagowners"] {
+					owners[name] = obj["owners"]
+				}
+				return fmt.Sprint(owners)
+			},
+			wantCreate:  "map[tag:web:[group:eng]]",
+			wantUpdate:  "map[tag:db:[group:ops]]",
+			wantDeleted: "map[]",
+		},
+		{
+			name:        "acl",
+			newResource: NewACLResource,
+			create:      map[string]interface{}{"action": "accept", "src": []string{"group:eng"}, "dst": []string{"tag:web:443"}},
+			update:      map[string]interface{}{"action": "accept", "src": []string{"group:eng"}, "dst": []string{"tag:web:80,443"}},
+			server:      byIDField("acls", "dst"),
+			wantCreate:  "[tag:web:443]",
+			wantUpdate:  "[tag:web:80,443]",
+		},
+		{
+			name:        "ssh",
+			newResource: NewSSHResource,
+			create: map[string]interface{}{
+				"action": "check", "src": []string{"group:eng"}, "dst": []string{"tag:prod"}, "users": []string{"root"},
+				"check_period": "12h",
+			},
+			update: map[string]interface{}{
+				"action": "accept", "src": []string{"group:eng"}, "dst": []string{"tag:prod"}, "users": []string{"root", "ubuntu"},
+			},
+			server:     byIDField("ssh", "users"),
+			wantCreate: "[root]",
+			wantUpdate: "[root ubuntu]",
+		},
+		{
+			name:        "sshs",
+			newResource: NewSSHsResource,
+			create: map[string]interface{}{"rules": []interface{}{
+				map[string]interface{}{"action": "accept", "src": []string{"group:eng"}, "dst": []string{"tag:dev"}, "users": []string{"root"}},
+				map[string]interface{}{"action": "check", "src": []string{"group:ops"}, "dst": []string{"tag:prod"}, "users": []string{"root"}},
+			}},
+			update: map[string]interface{}{"rules": []interface{}{
+				map[string]interface{}{"action": "accept", "src": []string{"group:eng"}, "dst": []string{"tag:dev"}, "users": []string{"root"}},
+			}},
+			server: func(srv *fakeTACL) string {
+				var dsts []interface{}
+				for _, rule := range srv.byID["ssh"] {
+					dsts = append(dsts, rule["dst"])
+				}
+				return fmt.Sprint(dsts)
+			},
+			wantCreate:  "[[tag:dev] [tag:prod]]",
+			wantUpdate:  "[[tag:dev]]",
+			wantDeleted: "[]",
+		},
+		{
+			name:        "nodeattr",
+			newResource: NewNodeAttrResource,
+			create:      map[string]interface{}{"target": []string{"tag:web"}, "attr": []string{"funnel"}},
+			update:      map[string]interface{}{"target": []string{"tag:web", "tag:db"}, "attr": []string{"funnel"}},
+			server:      byIDField("nodeattrs", "target"),
+			wantCreate:  "[tag:web]",
+			wantUpdate:  "[tag:web tag:db]",
+		},
+		{
+			name:        "grant",
+			newResource: NewGrantResource,
+			create:      map[string]interface{}{"src": []string{"group:eng"}, "dst": []string{"tag:web"}, "ip": []string{"tcp:443"}},
+			update:      map[string]interface{}{"src": []string{"group:eng"}, "dst": []string{"tag:web"}, "ip": []string{"tcp:443", "tcp:80"}},
+			server:      byIDField("grants", "ip"),
+			wantCreate:  "[tcp:443]",
+			wantUpdate:  "[tcp:443 tcp:80]",
+		},
+		{
+			name:        "settings",
+			newResource: NewSettingsResource,
+			create:      map[string]interface{}{"disable_ipv4": true, "deletion_protection": false},
+			update:      map[string]interface{}{"disable_ipv4": false, "one_cgnat_route": "mac-always", "deletion_protection": false},
+			server: func(srv *fakeTACL) string {
+				return singletonField("settings", "disableIPv4")(srv) + " " + singletonField("settings", "oneCGNATRoute")(srv)
+			},
+			wantCreate:  "true <nil>",
+			wantUpdate:  "false mac-always",
+			wantDeleted: gone + " " + gone,
+		},
+		{
+			name:        "posture",
+			newResource: NewPostureResource,
+			create:      map[string]interface{}{"name": "latestMac", "rules": []string{"node:os == 'macos'"}},
+			update:      map[string]interface{}{"name": "latestMac", "rules": []string{"node:os == 'macos'", "node:tsVersion >= '1.60'"}},
+			server:      namedField("postures", "latestMac", "rules"),
+			wantCreate:  "[node:os == 'macos']",
+			wantUpdate:  "[node:os == 'macos' node:tsVersion >= '1.60']",
+		},
+		{
+			name:        "default_posture",
+			newResource: NewDefaultPostureResource,
+			create:      map[string]interface{}{"rules": []string{"posture:latestMac"}},
+			update:      map[string]interface{}{"rules": []string{"posture:latestMac", "posture:managed"}},
+			server:      singletonField("postures/default", "defaultSourcePosture"),
+			wantCreate:  "[posture:latestMac]",
+			wantUpdate:  "[posture:latestMac posture:managed]",
+		},
+		{
+			name:        "auto_approvers",
+			newResource: NewAutoApproversResource,
+			create:      map[string]interface{}{"routes": map[string]interface{}{"10.0.0.0/24": []string{"group:eng"}}},
+			update: map[string]interface{}{
+				"routes":    map[string]interface{}{"10.0.0.0/24": []string{"group:eng", "group:ops"}},
+				"exit_node": []string{"tag:exit"},
+			},
+			server: func(srv *fakeTACL) string {
+				return singletonField("autoapprovers", "routes")(srv) + " " + singletonField("autoapprovers", "exitNode")(srv)
+			},
+			wantCreate:  "map[10.0.0.0/24:[group:eng]] <nil>",
+			wantUpdate:  "map[10.0.0.0/24:[group:eng group:ops]] [tag:exit]",
+			wantDeleted: gone + " " + gone,
+		},
+		{
+			name:        "derpmap",
+			newResource: NewDERPMapResource,
+			create: map[string]interface{}{"regions": []interface{}{
+				map[string]interface{}{"region_id": 900, "region_code": "sea", "region_name": "Seattle", "nodes": []interface{}{
+					map[string]interface{}{"name": "900a", "region_id": 900, "host_name": "derp1.example.com", "ipv4": "192.0.2.10"},
+				}},
+			}},
+			update: map[string]interface{}{"regions": []interface{}{
+				map[string]interface{}{"region_id": 900, "region_code": "sea", "region_name": "Seattle", "nodes": []interface{}{
+					map[string]interface{}{"name": "900a", "region_id": 900, "host_name": "derp2.example.com", "ipv6": "2001:db8::10"},
+				}},
+			}},
+			server: func(srv *fakeTACL) string {
+				if srv.derpmap == nil {
+					return gone
+				}
+				regions, _ := srv.derpmap["regions"].(map[string]interface{})
+				region, _ := regions["900"].(map[string]interface{})
+				nodes, _ := region["nodes"].([]interface{})
+				if len(nodes) != 1 {
+					return fmt.Sprint(srv.derpmap)
+				}
+				node, _ := nodes[0].(map[string]interface{})
+				return fmt.Sprint(node["hostName"])
+			},
+			wantCreate: "derp1.example.com",
+			wantUpdate: "derp2.example.com",
+		},
+		{
+			name:        "acl_test",
+			newResource: NewACLTestResource,
+			create:      map[string]interface{}{"src": "alice@example.com", "accept": []string{"tag:web:443"}},
+			update:      map[string]interface{}{"src": "alice@example.com", "accept": []string{"tag:web:443"}, "deny": []string{"tag:db:5432"}},
+			server:      byIDField("tests", "deny"),
+			wantCreate:  "<nil>",
+			wantUpdate:  "[tag:db:5432]",
+		},
+		{
+			name:        "policy",
+			newResource: NewPolicyResource,
+			create:      map[string]interface{}{"document": "{\n  // who can reach what\n  \"acls\": [],\n}\n"},
+			update:      map[string]interface{}{"document": `{"acls": [{"action": "accept", "src": ["*"], "dst": ["*:*"]}]}`},
+			server: func(srv *fakeTACL) string {
+				if srv.policy == nil {
+					return gone
+				}
+				return string(srv.policy)
+			},
+			wantCreate: "{\n  // who can reach what\n  \"acls\": [],\n}\n",
+			wantUpdate: `{"acls": [{"action": "accept", "src": ["*"], "dst": ["*:*"]}]}`,
+			// tacl_policy only forgets the document
+			wantDeleted: `{"acls": [{"action": "accept", "src": ["*"], "dst": ["*:*"]}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFakeTACL(t)
+			r := newTestResource(t, newTestProvider(t, srv, nil), tt.newResource())
+
+			created, diags := r.create(r.values(tt.create))
+			requireNoErrors(t, diags)
+			if created.Raw.IsNull() {
+				t.Fatal("create left no state")
+			}
+			if got := tt.server(srv); got != tt.wantCreate {
+				t.Fatalf("server after create = %s, want %s", got, tt.wantCreate)
+			}
+			refreshed, diags := r.read(created)
+			requireNoErrors(t, diags)
+			if !refreshed.Raw.Equal(created.Raw) {
+				t.Fatalf("refresh after create changed state:\n got %v\nwant %v", refreshed.Raw, created.Raw)
+			}
+
+			updated, diags := r.update(refreshed, r.values(tt.update))
+			requireNoErrors(t, diags)
+			if got := tt.server(srv); got != tt.wantUpdate {
+				t.Fatalf("server after update = %s, want %s", got, tt.wantUpdate)
+			}
+			refreshed, diags = r.read(updated)
+			requireNoErrors(t, diags)
+			if !refreshed.Raw.Equal(updated.Raw) {
+				t.Fatalf("refresh after update changed state:\n got %v\nwant %v", refreshed.Raw, updated.Raw)
+			}
+
+			removed, diags := r.delete(refreshed)
+			if diags.HasError() || !removed {
+				t.Fatalf("delete: removed %v, errors %v", removed, diags.Errors())
+			}
+			wantDeleted := tt.wantDeleted
+			if wantDeleted == "" {
+				wantDeleted = gone
+			}
+			if got := tt.server(srv); got != wantDeleted {
+				t.Fatalf("server after delete = %s, want %s", got, wantDeleted)
+			}
+		})
+	}
+}
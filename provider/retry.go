@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultMaxRetries = 3
+	retryMinBackoff   = 500 * time.Millisecond
+	retryMaxBackoff   = 10 * time.Second
+)
+
+// retryTransport => RoundTripper that retries transient TACL failures with
+// exponential backoff and logs per-request retry metrics. Unless noJitter is
+// set, each computed backoff is replaced by a random wait between 0 and that
+// value ("full jitter"), so many resources failing together don't retry in
+// lockstep against a recovering server.
+//
+// 429 and 503 mean the server didn't process the request, so any method is
+// retried. Connection errors, 502 and 504 are ambiguous, so only idempotent
+// methods (or requests carrying an Idempotency-Key) are retried on those.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	noJitter   bool
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	start := time.Now()
+
+	var res *http.Response
+	var err error
+	attempt := 0
+	for {
+		attempt++
+		res, err = t.base.RoundTrip(req)
+		if attempt > t.maxRetries || !shouldRetry(req, res, err) {
+			break
+		}
+		// A body can only be re-sent if the request knows how to rewind it
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				break
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				break
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		wait := retryBackoff(attempt, res, !t.noJitter)
+		if res != nil {
+			res.Body.Close()
+		}
+		tflog.Debug(ctx, "Retrying TACL request", map[string]interface{}{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt,
+			"wait_ms": wait.Milliseconds(),
+		})
+		if sleepErr := sleepCtx(ctx, wait); sleepErr != nil {
+			res, err = nil, sleepErr
+			break
+		}
+	}
+
+	status := 0
+	if res != nil {
+		status = res.StatusCode
+	}
+	tflog.Info(ctx, "TACL request finished", map[string]interface{}{
+		"method":      req.Method,
+		"url":         req.URL.String(),
+		"attempts":    attempt,
+		"duration_ms": time.Since(start).Milliseconds(),
+		"status":      status,
+	})
+	return res, err
+}
+
+// shouldRetry => whether a failed attempt is worth repeating
+func shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	replayable := isIdempotentMethod(req.Method) || req.Header.Get("Idempotency-Key") != ""
+	if err != nil {
+		// An *APIError here means TACL answered (see envelopeTransport)
+		var apiErr *APIError
+		return replayable && !errors.As(err, &apiErr)
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return replayable
+	}
+	return false
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryBackoff => wait before the next attempt: the server's Retry-After (in
+// seconds) if given, else exponential from retryMinBackoff, capped at
+// retryMaxBackoff. With jitter the exponential wait is drawn uniformly from
+// [0, cap]; an explicit Retry-After is always honored as-is.
+func retryBackoff(attempt int, res *http.Response, jitter bool) time.Duration {
+	if res != nil {
+		if secs, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && secs >= 0 {
+			return min(time.Duration(secs)*time.Second, retryMaxBackoff)
+		}
+	}
+	wait := retryMinBackoff << (attempt - 1)
+	if wait <= 0 || wait > retryMaxBackoff {
+		wait = retryMaxBackoff
+	}
+	if jitter {
+		wait = rand.N(wait + 1)
+	}
+	return wait
+}
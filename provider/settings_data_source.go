@@ -28,6 +28,7 @@ func NewSettingsDataSource() datasource.DataSource {
 type settingsDataSource struct {
 	httpClient *http.Client
 	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
 }
 
 type settingsDSModel struct {
@@ -45,8 +46,9 @@ func (d *settingsDataSource) Configure(ctx context.Context, req datasource.Confi
 	if !ok {
 		return
 	}
-	d.httpClient = p.httpClient
+	d.httpClient = p.dsHTTPClient
 	d.endpoint = p.endpoint
+	d.logMasks = p.logMasks
 }
 
 func (d *settingsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -56,7 +58,8 @@ func (d *settingsDataSource) Metadata(ctx context.Context, req datasource.Metada
 // We have no required inputs, we just read the single Settings if it exists
 func (d *settingsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Data source for reading the single /settings object.",
+		Description: "Data source for reading the single /settings object: the policy file's top-level settings " +
+			"(disableIPv4, OneCGNATRoute, randomizeClientPort).",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Always 'settings' if found.",
@@ -79,6 +82,7 @@ func (d *settingsDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 }
 
 func (d *settingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = maskLogCtx(ctx, d.logMasks)
 	// We read the single object, no input needed
 	var data settingsDSModel
 
@@ -87,11 +91,11 @@ func (d *settingsDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	body, err := doSettingsDSRequest(ctx, d.httpClient, http.MethodGet, getURL, nil)
 	if err != nil {
-		if IsNotFound(err) {
-			// no settings => no state
+		if isNotFound(err) {
+			addDSNotFoundError(&resp.Diagnostics, "Settings", "")
 			return
 		}
-		resp.Diagnostics.AddError("Read settings DS error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read settings DS error", err)
 		return
 	}
 
@@ -152,7 +156,7 @@ func doSettingsDSRequest(ctx context.Context, client *http.Client, method, url s
 	}
 	if res.StatusCode >= 300 {
 		msg, _ := io.ReadAll(res.Body)
-		return nil, fmt.Errorf("TACL returned %d: %s", res.StatusCode, msg)
+		return nil, newAPIError(res, msg)
 	}
 
 	return io.ReadAll(res.Body)
@@ -8,8 +8,13 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -29,6 +34,7 @@ func NewSettingsResource() resource.Resource {
 type settingsResource struct {
 	httpClient *http.Client
 	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
 }
 
 // We store ID="settings" once created, plus the 3 fields
@@ -37,8 +43,12 @@ type settingsResourceModel struct {
 	DisableIPv4         types.Bool   `tfsdk:"disable_ipv4"`          // from JSON: "disableIPv4"
 	OneCGNATRoute       types.String `tfsdk:"one_cgnat_route"`       // from JSON: "oneCGNATRoute"
 	RandomizeClientPort types.Bool   `tfsdk:"randomize_client_port"` // from JSON: "randomizeClientPort"
+	DeletionProtection  types.Bool   `tfsdk:"deletion_protection"`   // client-side only
 }
 
+// settingsDeletionProtection => settings are tailnet-wide, so protect by default
+const settingsDeletionProtection = true
+
 func (r *settingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -49,6 +59,7 @@ func (r *settingsResource) Configure(ctx context.Context, req resource.Configure
 	}
 	r.httpClient = provider.httpClient
 	r.endpoint = provider.endpoint
+	r.logMasks = provider.logMasks
 }
 
 func (r *settingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -56,53 +67,79 @@ func (r *settingsResource) Metadata(ctx context.Context, req resource.MetadataRe
 	resp.TypeName = req.ProviderTypeName + "_settings"
 }
 
-// We define the 3 fields + computed ID
+// We define the 3 fields + computed ID.
+// Each field is Optional+Computed: fields left out of config keep whatever
+// value the server already has.
 func (r *settingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages the single Settings object at /settings.",
+		Description: "Manages the single Settings object at /settings. Only the fields set in config are managed; the rest keep their server value. " +
+			"/settings mirrors the policy file's top-level settings (disableIPv4, OneCGNATRoute, randomizeClientPort); " +
+			"any other field the server returns is sent back unchanged on update. " +
+			"DNS settings such as MagicDNS and nameservers are not part of the policy file and are not managed here.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Always 'settings' once created.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"disable_ipv4": schema.BoolAttribute{
 				Description: "Disable IPv4 setting (disableIPv4).",
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"one_cgnat_route": schema.StringAttribute{
-				Description: "OneCGNATRoute setting.",
-				Required:    true,
+				Description: "OneCGNATRoute setting: empty (unset), 'mac-always', 'mac-never', or a CIDR.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					oneCGNATRouteValidator{},
+				},
 			},
 			"randomize_client_port": schema.BoolAttribute{
 				Description: "Randomize client port (randomizeClientPort).",
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
+			"deletion_protection": deletionProtectionAttribute(settingsDeletionProtection),
 		},
 	}
 }
 
 // CREATE => POST /settings => must not already exist
 func (r *settingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data settingsResourceModel
-	diags := req.Plan.Get(ctx, &data)
+	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Build the JSON payload from the plan
-	payload := map[string]interface{}{
-		"disableIPv4":         data.DisableIPv4.ValueBool(),
-		"oneCGNATRoute":       data.OneCGNATRoute.ValueString(),
-		"randomizeClientPort": data.RandomizeClientPort.ValueBool(),
+	// Start from whatever the server has (if anything) and overlay config
+	current, err := r.fetchCurrent(ctx)
+	if err != nil {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read settings error", err)
+		return
 	}
+	payload := mergeSettingsPayload(current, data)
 
 	postURL := fmt.Sprintf("%s/settings", r.endpoint)
-	tflog.Debug(ctx, "Creating Settings via TACL", map[string]interface{}{"url": postURL, "payload": payload})
+	tflog.Debug(ctx, "Creating Settings via TACL", map[string]interface{}{"url": postURL, "payload": redactForLog(payload)})
 
 	body, err := doSettingsRequest(ctx, r.httpClient, http.MethodPost, postURL, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Create settings error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Create settings error", err)
 		return
 	}
 
@@ -115,17 +152,8 @@ func (r *settingsResource) Create(ctx context.Context, req resource.CreateReques
 
 	// ID => "settings"
 	data.ID = types.StringValue("settings")
-
-	// Optionally read back the final fields
-	if disable, ok := created["disableIPv4"].(bool); ok {
-		data.DisableIPv4 = types.BoolValue(disable)
-	}
-	if route, ok := created["oneCGNATRoute"].(string); ok {
-		data.OneCGNATRoute = types.StringValue(route)
-	}
-	if randPort, ok := created["randomizeClientPort"].(bool); ok {
-		data.RandomizeClientPort = types.BoolValue(randPort)
-	}
+	setSettingsFromResponse(&data, payload, created)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("deletion_protection"), &data.DeletionProtection)...)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -133,6 +161,7 @@ func (r *settingsResource) Create(ctx context.Context, req resource.CreateReques
 
 // READ => GET /settings => returns JSON or empty struct
 func (r *settingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data settingsResourceModel
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -145,12 +174,12 @@ func (r *settingsResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	body, err := doSettingsRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
 	if err != nil {
-		if IsNotFound(err) {
+		if isNotFound(err) {
 			// no settings => remove from state
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Read settings error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read settings error", err)
 		return
 	}
 
@@ -163,55 +192,42 @@ func (r *settingsResource) Read(ctx context.Context, req resource.ReadRequest, r
 	// If server returned an empty object => TACL may treat that as "no settings"
 	// We'll consider that as existing, but with defaults
 	data.ID = types.StringValue("settings")
-
-	if disable, ok := fetched["disableIPv4"].(bool); ok {
-		data.DisableIPv4 = types.BoolValue(disable)
-	} else {
-		data.DisableIPv4 = types.BoolValue(false)
-	}
-
-	if route, ok := fetched["oneCGNATRoute"].(string); ok {
-		data.OneCGNATRoute = types.StringValue(route)
-	} else {
-		data.OneCGNATRoute = types.StringValue("")
-	}
-
-	if randPort, ok := fetched["randomizeClientPort"].(bool); ok {
-		data.RandomizeClientPort = types.BoolValue(randPort)
-	} else {
-		data.RandomizeClientPort = types.BoolValue(false)
-	}
+	setSettingsFromResponse(&data, map[string]interface{}{}, fetched)
+	data.DeletionProtection = deletionProtectionOrDefault(data.DeletionProtection, settingsDeletionProtection)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
-// UPDATE => PUT /settings => must exist first
+// UPDATE => PUT /settings => must exist first.
+// We read the current object first so fields not in config aren't clobbered.
 func (r *settingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data settingsResourceModel
-	diags := req.Plan.Get(ctx, &data)
+	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	payload := map[string]interface{}{
-		"disableIPv4":         data.DisableIPv4.ValueBool(),
-		"oneCGNATRoute":       data.OneCGNATRoute.ValueString(),
-		"randomizeClientPort": data.RandomizeClientPort.ValueBool(),
+	current, err := r.fetchCurrent(ctx)
+	if err != nil {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read settings error", err)
+		return
 	}
+	payload := mergeSettingsPayload(current, data)
 
 	putURL := fmt.Sprintf("%s/settings", r.endpoint)
 	tflog.Debug(ctx, "Updating Settings via TACL", map[string]interface{}{"url": putURL})
 
 	body, err := doSettingsRequest(ctx, r.httpClient, http.MethodPut, putURL, payload)
 	if err != nil {
-		if IsNotFound(err) {
+		if isNotFound(err) {
 			// no existing => remove from state
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Update settings error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Update settings error", err)
 		return
 	}
 
@@ -222,33 +238,97 @@ func (r *settingsResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	data.ID = types.StringValue("settings")
-
-	if disable, ok := updated["disableIPv4"].(bool); ok {
-		data.DisableIPv4 = types.BoolValue(disable)
-	}
-	if route, ok := updated["oneCGNATRoute"].(string); ok {
-		data.OneCGNATRoute = types.StringValue(route)
-	}
-	if randPort, ok := updated["randomizeClientPort"].(bool); ok {
-		data.RandomizeClientPort = types.BoolValue(randPort)
-	}
+	setSettingsFromResponse(&data, payload, updated)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("deletion_protection"), &data.DeletionProtection)...)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
-// DELETE => DELETE /settings
+// DELETE => DELETE /settings, unless deletion_protection
 func (r *settingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var protected types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &protected)...)
+	if resp.Diagnostics.HasError() || !checkDeletionProtection(&resp.Diagnostics, protected, settingsDeletionProtection, "settings") {
+		return
+	}
+
 	delURL := fmt.Sprintf("%s/settings", r.endpoint)
 	_, err := doSettingsRequest(ctx, r.httpClient, http.MethodDelete, delURL, nil)
-	if err != nil && !IsNotFound(err) {
-		resp.Diagnostics.AddError("Delete settings error", err.Error())
+	if err != nil && !isNotFound(err) {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Delete settings error", err)
 		return
 	}
 	// remove from state
 	resp.State.RemoveResource(ctx)
 }
 
+// fetchCurrent => GET /settings, or an empty map if none exist yet
+func (r *settingsResource) fetchCurrent(ctx context.Context) (map[string]interface{}, error) {
+	getURL := fmt.Sprintf("%s/settings", r.endpoint)
+	body, err := doSettingsRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return map[string]interface{}{}, nil
+		}
+		return nil, err
+	}
+	current := map[string]interface{}{}
+	if err := json.Unmarshal(body, &current); err != nil {
+		return nil, fmt.Errorf("parse current settings: %w", err)
+	}
+	return current, nil
+}
+
+// mergeSettingsPayload => current server settings with every field that is
+// actually set in config laid on top. Unknown server keys pass through untouched.
+func mergeSettingsPayload(current map[string]interface{}, config settingsResourceModel) map[string]interface{} {
+	payload := make(map[string]interface{}, len(current)+3)
+	for k, v := range current {
+		payload[k] = v
+	}
+	if !config.DisableIPv4.IsNull() && !config.DisableIPv4.IsUnknown() {
+		payload["disableIPv4"] = config.DisableIPv4.ValueBool()
+	}
+	if !config.OneCGNATRoute.IsNull() && !config.OneCGNATRoute.IsUnknown() {
+		payload["oneCGNATRoute"] = config.OneCGNATRoute.ValueString()
+	}
+	if !config.RandomizeClientPort.IsNull() && !config.RandomizeClientPort.IsUnknown() {
+		payload["randomizeClientPort"] = config.RandomizeClientPort.ValueBool()
+	}
+	return payload
+}
+
+// setSettingsFromResponse => fill all three fields from the server response,
+// falling back to what we sent, then to zero values.
+func setSettingsFromResponse(data *settingsResourceModel, sent, got map[string]interface{}) {
+	pick := func(key string) interface{} {
+		if v, ok := got[key]; ok && v != nil {
+			return v
+		}
+		return sent[key]
+	}
+
+	if disable, ok := pick("disableIPv4").(bool); ok {
+		data.DisableIPv4 = types.BoolValue(disable)
+	} else {
+		data.DisableIPv4 = types.BoolValue(false)
+	}
+
+	if route, ok := pick("oneCGNATRoute").(string); ok {
+		data.OneCGNATRoute = types.StringValue(route)
+	} else {
+		data.OneCGNATRoute = types.StringValue("")
+	}
+
+	if randPort, ok := pick("randomizeClientPort").(bool); ok {
+		data.RandomizeClientPort = types.BoolValue(randPort)
+	} else {
+		data.RandomizeClientPort = types.BoolValue(false)
+	}
+}
+
 // doSettingsRequest => helper for single-object /settings calls
 func doSettingsRequest(ctx context.Context, client *http.Client, method, url string, payload interface{}) ([]byte, error) {
 	var body io.Reader
@@ -277,7 +357,7 @@ func doSettingsRequest(ctx context.Context, client *http.Client, method, url str
 	}
 	if resp.StatusCode >= 300 {
 		msg, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("TACL returned %d: %s", resp.StatusCode, msg)
+		return nil, newAPIError(resp, msg)
 	}
 
 	return io.ReadAll(resp.Body)
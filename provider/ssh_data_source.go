@@ -32,6 +32,7 @@ func NewSSHDataSource() datasource.DataSource {
 type sshDataSource struct {
 	httpClient *http.Client
 	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
 }
 
 // sshDataSourceModel => data source model
@@ -43,6 +44,8 @@ type sshDataSourceModel struct {
 	Users       []types.String `tfsdk:"users"`
 	CheckPeriod types.String   `tfsdk:"check_period"`
 	AcceptEnv   []types.String `tfsdk:"accept_env"`
+
+	FailIfMissing types.Bool `tfsdk:"fail_if_missing"`
 }
 
 // --------------------------------------------------------------------------------
@@ -57,8 +60,9 @@ func (d *sshDataSource) Configure(ctx context.Context, req datasource.ConfigureR
 	if !ok {
 		return
 	}
-	d.httpClient = p.httpClient
+	d.httpClient = p.dsHTTPClient
 	d.endpoint = p.endpoint
+	d.logMasks = p.logMasks
 }
 
 func (d *sshDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -69,6 +73,10 @@ func (d *sshDataSource) Schema(ctx context.Context, req datasource.SchemaRequest
 	resp.Schema = schema.Schema{
 		Description: "Data source for reading a single SSH rule by UUID from /ssh/:id.",
 		Attributes: map[string]schema.Attribute{
+			"fail_if_missing": schema.BoolAttribute{
+				Description: "If true, a lookup that finds nothing is an error. Defaults to false: a warning, with attributes left null.",
+				Optional:    true,
+			},
 			"id": schema.StringAttribute{
 				Description: "The stable UUID of the SSH rule in TACL.",
 				Required:    true,
@@ -110,6 +118,7 @@ func (d *sshDataSource) Schema(ctx context.Context, req datasource.SchemaRequest
 // --------------------------------------------------------------------------------
 
 func (d *sshDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = maskLogCtx(ctx, d.logMasks)
 	var data sshDataSourceModel
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -131,11 +140,11 @@ func (d *sshDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 
 	body, err := doSSHDSRequest(ctx, d.httpClient, http.MethodGet, getURL, nil)
 	if err != nil {
-		if IsNotFound(err) {
-			// Not found => no state
+		if isNotFound(err) {
+			addDSMissingDiagnostic(&resp.Diagnostics, data.FailIfMissing, "SSH rule", fmt.Sprintf("with id %q", id))
 			return
 		}
-		resp.Diagnostics.AddError("Read SSH DS error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read SSH DS error", err)
 		return
 	}
 
@@ -194,7 +203,7 @@ func doSSHDSRequest(ctx context.Context, client *http.Client, method, url string
 	}
 	if res.StatusCode >= 300 {
 		msg, _ := io.ReadAll(res.Body)
-		return nil, fmt.Errorf("TACL returned HTTP %d: %s", res.StatusCode, string(msg))
+		return nil, newAPIError(res, msg)
 	}
 
 	return io.ReadAll(res.Body)
@@ -12,6 +12,9 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -25,11 +28,15 @@ type TaclSSHResponse struct {
 	Users       []string `json:"users,omitempty"`
 	CheckPeriod string   `json:"checkPeriod,omitempty"`
 	AcceptEnv   []string `json:"acceptEnv,omitempty"`
+	Comment     string   `json:"comment,omitempty"`
+
+	auditFields // updatedBy/updatedAt, if TACL records them
 }
 
 var (
-	_ resource.Resource              = &sshResource{}
-	_ resource.ResourceWithConfigure = &sshResource{}
+	_ resource.Resource                 = &sshResource{}
+	_ resource.ResourceWithConfigure    = &sshResource{}
+	_ resource.ResourceWithUpgradeState = &sshResource{}
 )
 
 func NewSSHResource() resource.Resource {
@@ -37,8 +44,14 @@ func NewSSHResource() resource.Resource {
 }
 
 type sshResource struct {
-	httpClient *http.Client
-	endpoint   string
+	httpClient     *http.Client
+	endpoint       string
+	logMasks       []string // provider secrets, masked in tflog (see maskLogCtx)
+	normalizeLists bool     // provider's normalize_lists
+	readAfterWrite readAfterWrite
+	debug          bool // provider's debug => fill raw_json
+
+	preserveUnknownFields bool // provider's preserve_unknown_fields
 }
 
 type sshResourceModel struct {
@@ -49,6 +62,11 @@ type sshResourceModel struct {
 	Users       []types.String `tfsdk:"users"`
 	CheckPeriod types.String   `tfsdk:"check_period"`
 	AcceptEnv   []types.String `tfsdk:"accept_env"`
+	Comment     types.String   `tfsdk:"comment"`
+	RawJSON     types.String   `tfsdk:"raw_json"` // last response body, debug only
+
+	LastModifiedBy types.String `tfsdk:"last_modified_by"` // updatedBy, if TACL records it
+	LastModifiedAt types.String `tfsdk:"last_modified_at"` // updatedAt, if TACL records it
 }
 
 func (r *sshResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -61,6 +79,11 @@ func (r *sshResource) Configure(ctx context.Context, req resource.ConfigureReque
 	}
 	r.httpClient = p.httpClient
 	r.endpoint = p.endpoint
+	r.logMasks = p.logMasks
+	r.normalizeLists = p.normalizeLists
+	r.readAfterWrite = p.readAfterWrite
+	r.debug = p.debug
+	r.preserveUnknownFields = p.preserveUnknownFields
 }
 
 func (r *sshResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -69,11 +92,16 @@ func (r *sshResource) Metadata(ctx context.Context, req resource.MetadataRequest
 
 func (r *sshResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// v1 => empty accept_env / check_period are stored as null
+		Version:     1,
 		Description: "Manages a single SSH rule by stable ID in TACL’s /ssh.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Stable UUID of the SSH rule.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"action": schema.StringAttribute{
 				Description: "SSH action: 'accept' or 'check'.",
@@ -85,14 +113,21 @@ func (r *sshResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				ElementType: types.StringType,
 			},
 			"dst": schema.ListAttribute{
-				Description: "Destinations (tags, host:port, etc.).",
+				Description: "Destinations (tags, host:port, etc.). Entries must be non-empty, without spaces; a port, if given, must be valid.",
 				Required:    true,
 				ElementType: types.StringType,
+				Validators: []validator.List{
+					nonEmptyListValidator{},
+					sshDstValidator{},
+				},
 			},
 			"users": schema.ListAttribute{
 				Description: "List of SSH users allowed.",
 				Required:    true,
 				ElementType: types.StringType,
+				Validators: []validator.List{
+					nonEmptyListValidator{},
+				},
 			},
 			"check_period": schema.StringAttribute{
 				Description: "Optional duration if action='check', e.g. '12h'.",
@@ -103,12 +138,90 @@ func (r *sshResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"comment": schema.StringAttribute{
+				Description: "Optional free-form comment. Sent to TACL; if the server doesn't store it, the configured value is kept in state.",
+				Optional:    true,
+			},
+			"last_modified_by": lastModifiedByAttribute(),
+			"last_modified_at": lastModifiedAtAttribute(),
+			"raw_json":         rawJSONAttribute(),
+		},
+	}
+}
+
+// sshResourceModelV0 => tacl_ssh state as written by schema version 0
+type sshResourceModelV0 struct {
+	ID          types.String   `tfsdk:"id"`
+	Action      types.String   `tfsdk:"action"`
+	Src         []types.String `tfsdk:"src"`
+	Dst         []types.String `tfsdk:"dst"`
+	Users       []types.String `tfsdk:"users"`
+	CheckPeriod types.String   `tfsdk:"check_period"`
+	AcceptEnv   []types.String `tfsdk:"accept_env"`
+	Comment     types.String   `tfsdk:"comment"`
+}
+
+// sshResourceSchemaV0 => tacl_ssh schema version 0, frozen; don't edit it
+// along with Schema.
+func sshResourceSchemaV0() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":           schema.StringAttribute{Computed: true},
+			"action":       schema.StringAttribute{Required: true},
+			"src":          schema.ListAttribute{Required: true, ElementType: types.StringType},
+			"dst":          schema.ListAttribute{Required: true, ElementType: types.StringType},
+			"users":        schema.ListAttribute{Required: true, ElementType: types.StringType},
+			"check_period": schema.StringAttribute{Optional: true},
+			"accept_env":   schema.ListAttribute{Optional: true, ElementType: types.StringType},
+			"comment":      schema.StringAttribute{Optional: true},
+		},
+	}
+}
+
+// UpgradeState => v0 state could hold [] / "" for accept_env / check_period
+// where the provider now writes null; normalize so the first refresh is clean.
+// raw_json and last_modified_* didn't exist in v0 and start out null.
+func (r *sshResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	priorSchema := sshResourceSchemaV0()
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior sshResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := sshResourceModel{
+					ID:             prior.ID,
+					Action:         prior.Action,
+					Src:            prior.Src,
+					Dst:            prior.Dst,
+					Users:          prior.Users,
+					CheckPeriod:    prior.CheckPeriod,
+					AcceptEnv:      prior.AcceptEnv,
+					Comment:        prior.Comment,
+					RawJSON:        types.StringNull(),
+					LastModifiedBy: types.StringNull(),
+					LastModifiedAt: types.StringNull(),
+				}
+				if len(data.AcceptEnv) == 0 {
+					data.AcceptEnv = nilListOfString()
+				}
+				if data.CheckPeriod.ValueString() == "" {
+					data.CheckPeriod = types.StringNull()
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
 		},
 	}
 }
 
 // CREATE => POST /ssh
 func (r *sshResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var plan sshResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -118,22 +231,26 @@ func (r *sshResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 	payload := map[string]interface{}{
 		"action":      plan.Action.ValueString(),
-		"src":         toGoStringSlice(plan.Src),
-		"dst":         toGoStringSlice(plan.Dst),
-		"users":       toGoStringSlice(plan.Users),
+		"src":         listPayload(plan.Src, r.normalizeLists),
+		"dst":         listPayload(plan.Dst, r.normalizeLists),
+		"users":       listPayload(plan.Users, r.normalizeLists),
 		"checkPeriod": plan.CheckPeriod.ValueString(),
 		"acceptEnv":   toGoStringSlice(plan.AcceptEnv),
+		"comment":     plan.Comment.ValueString(),
 	}
 
+	// The Idempotency-Key lets the server dedupe a retried POST
 	postURL := fmt.Sprintf("%s/ssh", r.endpoint)
+	ctx, idemKey := withIdempotencyKey(ctx)
 	tflog.Debug(ctx, "Creating SSH rule", map[string]interface{}{
-		"url":     postURL,
-		"payload": payload,
+		"url":             postURL,
+		"payload":         redactForLog(payload),
+		"idempotency_key": idemKey,
 	})
 
 	body, err := doSSHIDRequest(ctx, r.httpClient, http.MethodPost, postURL, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Create SSH error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Create SSH error", err)
 		return
 	}
 
@@ -143,11 +260,29 @@ func (r *sshResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	// Optionally confirm the new rule is readable (replication lag)
+	getURL := fmt.Sprintf("%s/ssh/%s", r.endpoint, created.ID)
+	fetched, err := awaitCreated(ctx, r.readAfterWrite, func() ([]byte, error) {
+		return doSSHIDRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
+	})
+	if err != nil {
+		addReadAfterWriteWarning(&resp.Diagnostics, "SSH rule", err)
+	} else if fetched != nil {
+		if e := json.Unmarshal(fetched, &created); e != nil {
+			resp.Diagnostics.AddError("Parse read response error", e.Error())
+			return
+		}
+		body = fetched
+	}
+
 	plan.ID = types.StringValue(created.ID)
 	plan.Action = types.StringValue(created.Action)
-	plan.Src = toTerraformStringSlice(created.Src)
-	plan.Dst = toTerraformStringSlice(created.Dst)
-	plan.Users = toTerraformStringSlice(created.Users)
+	plan.Src = normalizedOrPrior(created.Src, plan.Src)
+	plan.Dst = normalizedOrPrior(created.Dst, plan.Dst)
+	plan.Users = normalizedOrPrior(created.Users, plan.Users)
+	plan.Comment = commentOrPrior(created.Comment, plan.Comment)
+	plan.RawJSON = rawJSONValue(r.debug, body)
+	plan.LastModifiedBy, plan.LastModifiedAt = created.auditValues()
 
 	if created.CheckPeriod != "" {
 		plan.CheckPeriod = types.StringValue(created.CheckPeriod)
@@ -168,6 +303,7 @@ func (r *sshResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 // READ => GET /ssh/:id
 func (r *sshResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data sshResourceModel
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -193,7 +329,7 @@ func (r *sshResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Read SSH error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read SSH error", err)
 		return
 	}
 
@@ -205,9 +341,12 @@ func (r *sshResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 	data.ID = types.StringValue(fetched.ID)
 	data.Action = types.StringValue(fetched.Action)
-	data.Src = toTerraformStringSlice(fetched.Src)
-	data.Dst = toTerraformStringSlice(fetched.Dst)
-	data.Users = toTerraformStringSlice(fetched.Users)
+	data.Src = normalizedOrPrior(fetched.Src, data.Src)
+	data.Dst = normalizedOrPrior(fetched.Dst, data.Dst)
+	data.Users = normalizedOrPrior(fetched.Users, data.Users)
+	data.Comment = commentOrPrior(fetched.Comment, data.Comment)
+	data.RawJSON = rawJSONValue(r.debug, body)
+	data.LastModifiedBy, data.LastModifiedAt = fetched.auditValues()
 
 	if fetched.CheckPeriod != "" {
 		data.CheckPeriod = types.StringValue(fetched.CheckPeriod)
@@ -227,6 +366,7 @@ func (r *sshResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 // UPDATE => PUT /ssh => payload { "id":"...", "rule": {...} }
 func (r *sshResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var old sshResourceModel
 	diags := req.State.Get(ctx, &old)
 	resp.Diagnostics.Append(diags...)
@@ -248,22 +388,44 @@ func (r *sshResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
+	rule := map[string]interface{}{
+		"action":      plan.Action.ValueString(),
+		"src":         listPayload(plan.Src, r.normalizeLists),
+		"dst":         listPayload(plan.Dst, r.normalizeLists),
+		"users":       listPayload(plan.Users, r.normalizeLists),
+		"checkPeriod": plan.CheckPeriod.ValueString(),
+		"acceptEnv":   toGoStringSlice(plan.AcceptEnv),
+		"comment":     plan.Comment.ValueString(),
+	}
+
+	// preserve_unknown_fields => carry over rule fields this provider doesn't model
+	if r.preserveUnknownFields {
+		getURL := fmt.Sprintf("%s/ssh/%s", r.endpoint, id)
+		current, err := doSSHIDRequest(ctx, r.httpClient, http.MethodGet, getURL, nil)
+		if err != nil {
+			if isNotFound(err) {
+				resp.State.RemoveResource(ctx)
+				return
+			}
+			addAPIErrorDiagnostic(&resp.Diagnostics, "Read SSH before update error", err)
+			return
+		}
+		rule, err = withUnknownFields(current, rule, jsonFieldNames(TaclSSHResponse{}))
+		if err != nil {
+			resp.Diagnostics.AddError("Merge SSH fields error", err.Error())
+			return
+		}
+	}
+
 	payload := map[string]interface{}{
-		"id": id,
-		"rule": map[string]interface{}{
-			"action":      plan.Action.ValueString(),
-			"src":         toGoStringSlice(plan.Src),
-			"dst":         toGoStringSlice(plan.Dst),
-			"users":       toGoStringSlice(plan.Users),
-			"checkPeriod": plan.CheckPeriod.ValueString(),
-			"acceptEnv":   toGoStringSlice(plan.AcceptEnv),
-		},
+		"id":   id,
+		"rule": rule,
 	}
 
 	putURL := fmt.Sprintf("%s/ssh", r.endpoint)
 	tflog.Debug(ctx, "Updating SSH rule", map[string]interface{}{
 		"url":     putURL,
-		"payload": payload,
+		"payload": redactForLog(payload),
 	})
 
 	body, err := doSSHIDRequest(ctx, r.httpClient, http.MethodPut, putURL, payload)
@@ -272,7 +434,7 @@ func (r *sshResource) Update(ctx context.Context, req resource.UpdateRequest, re
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Update SSH error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Update SSH error", err)
 		return
 	}
 
@@ -284,9 +446,12 @@ func (r *sshResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 	plan.ID = types.StringValue(updated.ID)
 	plan.Action = types.StringValue(updated.Action)
-	plan.Src = toTerraformStringSlice(updated.Src)
-	plan.Dst = toTerraformStringSlice(updated.Dst)
-	plan.Users = toTerraformStringSlice(updated.Users)
+	plan.Src = normalizedOrPrior(updated.Src, plan.Src)
+	plan.Dst = normalizedOrPrior(updated.Dst, plan.Dst)
+	plan.Users = normalizedOrPrior(updated.Users, plan.Users)
+	plan.Comment = commentOrPrior(updated.Comment, plan.Comment)
+	plan.RawJSON = rawJSONOnUpdate(plan.RawJSON, r.debug, body)
+	plan.LastModifiedBy, plan.LastModifiedAt = updated.auditValues()
 
 	if updated.CheckPeriod != "" {
 		plan.CheckPeriod = types.StringValue(updated.CheckPeriod)
@@ -306,6 +471,7 @@ func (r *sshResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 // DELETE => DELETE /ssh => { "id":"..." }
 func (r *sshResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data sshResourceModel
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -323,17 +489,13 @@ func (r *sshResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 	delURL := fmt.Sprintf("%s/ssh", r.endpoint)
 	tflog.Debug(ctx, "Deleting SSH rule", map[string]interface{}{
 		"url":     delURL,
-		"payload": delPayload,
+		"payload": redactForLog(delPayload),
 	})
 
 	_, err := doSSHIDRequest(ctx, r.httpClient, http.MethodDelete, delURL, delPayload)
-	if err != nil {
-		if isNotFound(err) {
-			// gone
-		} else {
-			resp.Diagnostics.AddError("Delete SSH error", err.Error())
-			return
-		}
+	if err != nil && !isNotFound(err) {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Delete SSH error", err)
+		return
 	}
 	resp.State.RemoveResource(ctx)
 }
@@ -357,6 +519,7 @@ func doSSHIDRequest(ctx context.Context, client *http.Client, method, url string
 		return nil, fmt.Errorf("failed to create SSH ID request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	setIdempotencyKey(ctx, req)
 
 	respHTTP, err := client.Do(req)
 	if err != nil {
@@ -369,7 +532,7 @@ func doSSHIDRequest(ctx context.Context, client *http.Client, method, url string
 	}
 	if respHTTP.StatusCode >= 300 {
 		msg, _ := io.ReadAll(respHTTP.Body)
-		return nil, fmt.Errorf("TACL returned %d: %s", respHTTP.StatusCode, string(msg))
+		return nil, newAPIError(respHTTP, msg)
 	}
 
 	return io.ReadAll(respHTTP.Body)
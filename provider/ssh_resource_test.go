@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSSHResource_UpgradeStateV0(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEnv      tftypes.Value
+		checkPeriod    tftypes.Value
+		wantEnvNull    bool
+		wantPeriodNull bool
+	}{
+		{
+			name:           "empty values become null",
+			acceptEnv:      tfStrings(listOf),
+			checkPeriod:    tfString(""),
+			wantEnvNull:    true,
+			wantPeriodNull: true,
+		},
+		{
+			name:           "null stays null",
+			acceptEnv:      tftypes.NewValue(listOf(tftypes.String), nil),
+			checkPeriod:    tftypes.NewValue(tftypes.String, nil),
+			wantEnvNull:    true,
+			wantPeriodNull: true,
+		},
+		{
+			name:        "set values are kept",
+			acceptEnv:   tfStrings(listOf, "GIT_*"),
+			checkPeriod: tfString("12h"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestResource(t, &taclProvider{}, NewSSHResource())
+			state, diags := r.upgradeState(0, map[string]tftypes.Value{
+				"id":           tfString("ssh-1"),
+				"action":       tfString("check"),
+				"src":          tfStrings(listOf, "group:eng"),
+				"dst":          tfStrings(listOf, "tag:prod"),
+				"users":        tfStrings(listOf, "root"),
+				"accept_env":   tt.acceptEnv,
+				"check_period": tt.checkPeriod,
+				"comment":      tfString("break-glass"),
+			})
+			requireNoErrors(t, diags)
+
+			var data sshResourceModel
+			requireNoErrors(t, state.Get(context.Background(), &data))
+			if got := data.AcceptEnv == nil; got != tt.wantEnvNull {
+				t.Errorf("accept_env null = %v, want %v (%v)", got, tt.wantEnvNull, data.AcceptEnv)
+			}
+			if got := data.CheckPeriod.IsNull(); got != tt.wantPeriodNull {
+				t.Errorf("check_period null = %v, want %v (%v)", got, tt.wantPeriodNull, data.CheckPeriod)
+			}
+			if data.ID.ValueString() != "ssh-1" || data.Action.ValueString() != "check" || data.Comment.ValueString() != "break-glass" {
+				t.Errorf("other attributes changed: %+v", data)
+			}
+			if !data.RawJSON.IsNull() || !data.LastModifiedBy.IsNull() || !data.LastModifiedAt.IsNull() {
+				t.Errorf("attributes added after v0 should start null: %+v", data)
+			}
+		})
+	}
+}
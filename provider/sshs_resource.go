@@ -0,0 +1,419 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure we match the Terraform Resource interfaces
+var (
+	_ resource.Resource              = &sshsResource{}
+	_ resource.ResourceWithConfigure = &sshsResource{}
+)
+
+// NewSSHsResource => constructor for "tacl_sshs" (plural)
+func NewSSHsResource() resource.Resource {
+	return &sshsResource{}
+}
+
+// sshsResource => manages the whole ordered /ssh list in one resource.
+// ID is always "ssh".
+type sshsResource struct {
+	httpClient     *http.Client
+	endpoint       string
+	logMasks       []string // provider secrets, masked in tflog (see maskLogCtx)
+	normalizeLists bool     // provider's normalize_lists
+}
+
+type sshsResourceModel struct {
+	ID      types.String    `tfsdk:"id"`       // always "ssh"
+	Rules   []sshsRuleModel `tfsdk:"rules"`    // in evaluation order
+	RuleIDs types.List      `tfsdk:"rule_ids"` // server IDs, same order as Rules
+}
+
+// sshsRuleModel => one element of `rules`; same fields as tacl_ssh
+type sshsRuleModel struct {
+	Action      types.String   `tfsdk:"action"`
+	Src         []types.String `tfsdk:"src"`
+	Dst         []types.String `tfsdk:"dst"`
+	Users       []types.String `tfsdk:"users"`
+	CheckPeriod types.String   `tfsdk:"check_period"`
+	AcceptEnv   []types.String `tfsdk:"accept_env"`
+	Comment     types.String   `tfsdk:"comment"`
+}
+
+func (r *sshsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p, ok := req.ProviderData.(*taclProvider)
+	if !ok {
+		return
+	}
+	r.httpClient = p.httpClient
+	r.endpoint = p.endpoint
+	r.logMasks = p.logMasks
+	r.normalizeLists = p.normalizeLists
+}
+
+func (r *sshsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sshs"
+}
+
+func (r *sshsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the full, ordered list of SSH rules in TACL’s /ssh. Rules not listed here are removed, " +
+			"so don't combine this with tacl_ssh resources.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Always 'ssh' once created.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "SSH rules in evaluation order. TACL's list is made to match this order exactly.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"action": schema.StringAttribute{
+							Description: "SSH action: 'accept' or 'check'.",
+							Required:    true,
+						},
+						"src": schema.ListAttribute{
+							Description: "Sources (tags, CIDRs).",
+							Required:    true,
+							ElementType: types.StringType,
+						},
+						"dst": schema.ListAttribute{
+							Description: "Destinations (tags, host:port, etc.). Entries must be non-empty, without spaces; a port, if given, must be valid.",
+							Required:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								nonEmptyListValidator{},
+								sshDstValidator{},
+							},
+						},
+						"users": schema.ListAttribute{
+							Description: "List of SSH users allowed.",
+							Required:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								nonEmptyListValidator{},
+							},
+						},
+						"check_period": schema.StringAttribute{
+							Description: "Optional duration if action='check', e.g. '12h'.",
+							Optional:    true,
+						},
+						"accept_env": schema.ListAttribute{
+							Description: "Optional list of environment variables to allow.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"comment": schema.StringAttribute{
+							Description: "Optional free-form comment. If the server doesn't store it, the configured value is kept in state.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"rule_ids": schema.ListAttribute{
+				Description: "TACL IDs of the rules, in the same order as `rules`.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// --------------------------------------------------------------------------------
+// Create / Update => make the server's list match plan, position by position
+// --------------------------------------------------------------------------------
+
+func (r *sshsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var plan sshsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *sshsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var plan sshsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// apply => reconcile against what's on the server right now (not just our old
+// state), then fill plan from the server's final list.
+func (r *sshsResource) apply(ctx context.Context, plan *sshsResourceModel, diags *diag.Diagnostics) {
+	current, err := r.fetchAll(ctx)
+	if err != nil && !isNotFound(err) {
+		addAPIErrorDiagnostic(diags, "Read SSH rules error", err)
+		return
+	}
+
+	desired := make([]TaclSSHResponse, 0, len(plan.Rules))
+	for _, rule := range plan.Rules {
+		desired = append(desired, r.rulePayload(rule))
+	}
+	if err := r.applyDiff(ctx, current, desired); err != nil {
+		addAPIErrorDiagnostic(diags, "Apply SSH rules error", err)
+		return
+	}
+
+	final, err := r.fetchAll(ctx)
+	if err != nil {
+		addAPIErrorDiagnostic(diags, "Read SSH rules error", err)
+		return
+	}
+	setSSHsState(plan, final)
+}
+
+// --------------------------------------------------------------------------------
+// Read => GET /ssh
+// --------------------------------------------------------------------------------
+
+func (r *sshsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var data sshsResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fetched, err := r.fetchAll(ctx)
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read SSH rules error", err)
+		return
+	}
+
+	setSSHsState(&data, fetched)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// --------------------------------------------------------------------------------
+// Delete => DELETE every rule we manage
+// --------------------------------------------------------------------------------
+
+func (r *sshsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var data sshsResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids, err := listToGoStrings(ctx, data.RuleIDs)
+	if err != nil {
+		resp.Diagnostics.AddError("Read rule_ids error", err.Error())
+		return
+	}
+	current := make([]TaclSSHResponse, 0, len(ids))
+	for _, id := range ids {
+		current = append(current, TaclSSHResponse{ID: id})
+	}
+	if err := r.applyDiff(ctx, current, nil); err != nil {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Delete SSH rules error", err)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// --------------------------------------------------------------------------------
+// Helpers
+// --------------------------------------------------------------------------------
+
+// fetchAll => GET /ssh (following pagination), in server order
+func (r *sshsResource) fetchAll(ctx context.Context) ([]TaclSSHResponse, error) {
+	getURL := fmt.Sprintf("%s/ssh", r.endpoint)
+	items, err := doListRequest(ctx, r.httpClient, getURL)
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]TaclSSHResponse, 0, len(items))
+	for _, raw := range items {
+		var rule TaclSSHResponse
+		if err := json.Unmarshal(raw, &rule); err != nil {
+			return nil, fmt.Errorf("parse SSH rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// applyDiff => make current look like desired, by position: PUT over rules
+// that differ, POST extra rules (TACL appends them, so order is preserved),
+// DELETE leftovers. Rules already matching are left alone.
+func (r *sshsResource) applyDiff(ctx context.Context, current, desired []TaclSSHResponse) error {
+	url := fmt.Sprintf("%s/ssh", r.endpoint)
+
+	for i, want := range desired {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if i < len(current) {
+			have := current[i]
+			want.ID = have.ID
+			if reflect.DeepEqual(sshRuleForCompare(have), sshRuleForCompare(want)) {
+				continue
+			}
+			payload := map[string]interface{}{"id": have.ID, "rule": sshRuleBody(want)}
+			tflog.Debug(ctx, "Updating SSH rule in place", map[string]interface{}{
+				"url":      url,
+				"position": i,
+				"payload":  redactForLog(payload),
+			})
+			if _, err := doSSHIDRequest(ctx, r.httpClient, http.MethodPut, url, payload); err != nil {
+				return err
+			}
+			continue
+		}
+
+		postCtx, idemKey := withIdempotencyKey(ctx)
+		body := sshRuleBody(want)
+		tflog.Debug(postCtx, "Appending SSH rule", map[string]interface{}{
+			"url":             url,
+			"position":        i,
+			"payload":         redactForLog(body),
+			"idempotency_key": idemKey,
+		})
+		if _, err := doSSHIDRequest(postCtx, r.httpClient, http.MethodPost, url, body); err != nil {
+			return err
+		}
+	}
+
+	for i := len(desired); i < len(current); i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		payload := map[string]string{"id": current[i].ID}
+		tflog.Debug(ctx, "Deleting SSH rule", map[string]interface{}{
+			"url":     url,
+			"payload": redactForLog(payload),
+		})
+		if _, err := doSSHIDRequest(ctx, r.httpClient, http.MethodDelete, url, payload); err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// rulePayload => one configured rule in the server's shape (no ID)
+func (r *sshsResource) rulePayload(rule sshsRuleModel) TaclSSHResponse {
+	return TaclSSHResponse{
+		Action:      rule.Action.ValueString(),
+		Src:         listPayload(rule.Src, r.normalizeLists),
+		Dst:         listPayload(rule.Dst, r.normalizeLists),
+		Users:       listPayload(rule.Users, r.normalizeLists),
+		CheckPeriod: rule.CheckPeriod.ValueString(),
+		AcceptEnv:   toGoStringSlice(rule.AcceptEnv),
+		Comment:     rule.Comment.ValueString(),
+	}
+}
+
+// sshRuleBody => same body tacl_ssh sends for a rule
+func sshRuleBody(rule TaclSSHResponse) map[string]interface{} {
+	return map[string]interface{}{
+		"action":      rule.Action,
+		"src":         rule.Src,
+		"dst":         rule.Dst,
+		"users":       rule.Users,
+		"checkPeriod": rule.CheckPeriod,
+		"acceptEnv":   rule.AcceptEnv,
+		"comment":     rule.Comment,
+	}
+}
+
+// sshRuleForCompare => rule with empty lists as nil, so [] and an omitted
+// field compare equal, and without server-side audit fields
+func sshRuleForCompare(rule TaclSSHResponse) TaclSSHResponse {
+	nilIfEmpty := func(ss []string) []string {
+		if len(ss) == 0 {
+			return nil
+		}
+		return ss
+	}
+	rule.Src = nilIfEmpty(rule.Src)
+	rule.Dst = nilIfEmpty(rule.Dst)
+	rule.Users = nilIfEmpty(rule.Users)
+	rule.AcceptEnv = nilIfEmpty(rule.AcceptEnv)
+	rule.auditFields = auditFields{}
+	return rule
+}
+
+// setSSHsState => rules/rule_ids from the server's list, keeping configured
+// spellings (normalize_lists, dropped comments) by position
+func setSSHsState(m *sshsResourceModel, rules []TaclSSHResponse) {
+	out := make([]sshsRuleModel, 0, len(rules))
+	ids := make([]string, 0, len(rules))
+	for i, rule := range rules {
+		var prior sshsRuleModel
+		if i < len(m.Rules) {
+			prior = m.Rules[i]
+		}
+		model := sshsRuleModel{
+			Action:      types.StringValue(rule.Action),
+			Src:         normalizedOrPrior(rule.Src, prior.Src),
+			Dst:         normalizedOrPrior(rule.Dst, prior.Dst),
+			Users:       normalizedOrPrior(rule.Users, prior.Users),
+			CheckPeriod: stringOrNull(rule.CheckPeriod),
+			AcceptEnv:   nilListOfString(),
+			Comment:     commentOrPrior(rule.Comment, prior.Comment),
+		}
+		if len(rule.AcceptEnv) > 0 {
+			model.AcceptEnv = toTerraformStringSlice(rule.AcceptEnv)
+		}
+		out = append(out, model)
+		ids = append(ids, rule.ID)
+	}
+
+	m.ID = types.StringValue("ssh")
+	m.Rules = out
+	m.RuleIDs, _ = goStringsToList(ids) // plain strings, can't fail
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure interface compliance
+var _ function.Function = &tagFunction{}
+
+// NewTagFunction => provider::tacl::tag(name)
+func NewTagFunction() function.Function {
+	return &tagFunction{}
+}
+
+// tagFunction => builds "tag:<name>" for ACL src/dst, tag owners, etc.
+type tagFunction struct{}
+
+func (f *tagFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "tag"
+}
+
+func (f *tagFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a Tailscale tag reference",
+		Description: "Returns \"tag:<name>\". A leading \"tag:\" on the input is accepted and not doubled.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "Tag name, e.g. 'dev'.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *tagFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	name = strings.TrimPrefix(name, "tag:")
+	if name == "" {
+		resp.Error = function.NewArgumentFuncError(0, "tag name must not be empty")
+		return
+	}
+	if strings.ContainsAny(name, ": \t\n") {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("tag name %q must not contain colons or whitespace", name))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, "tag:"+name))
+}
@@ -27,12 +27,15 @@ func NewTagOwnersDataSource() datasource.DataSource {
 type tagOwnersDataSource struct {
 	httpClient *http.Client
 	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
 }
 
 // dsModel => the DS schema model: user sets "name" => we read "owners"
 type tagOwnersDSModel struct {
 	Name   types.String   `tfsdk:"name"`   // user must provide the tag name
 	Owners []types.String `tfsdk:"owners"` // we populate from the server
+
+	FailIfMissing types.Bool `tfsdk:"fail_if_missing"`
 }
 
 func (d *tagOwnersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -43,8 +46,9 @@ func (d *tagOwnersDataSource) Configure(ctx context.Context, req datasource.Conf
 	if !ok {
 		return
 	}
-	d.httpClient = p.httpClient
+	d.httpClient = p.dsHTTPClient
 	d.endpoint = p.endpoint
+	d.logMasks = p.logMasks
 }
 
 func (d *tagOwnersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -55,6 +59,10 @@ func (d *tagOwnersDataSource) Schema(ctx context.Context, req datasource.SchemaR
 	resp.Schema = schema.Schema{
 		Description: "Data source for reading a single TagOwner by name from /tagowners/:name.",
 		Attributes: map[string]schema.Attribute{
+			"fail_if_missing": schema.BoolAttribute{
+				Description: "If true, a lookup that finds nothing is an error. Defaults to false: a warning, with attributes left null.",
+				Optional:    true,
+			},
 			"name": schema.StringAttribute{
 				Description: "Name of the tag (e.g. 'webserver') to look up.",
 				Required:    true,
@@ -69,6 +77,7 @@ func (d *tagOwnersDataSource) Schema(ctx context.Context, req datasource.SchemaR
 }
 
 func (d *tagOwnersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = maskLogCtx(ctx, d.logMasks)
 	var data tagOwnersDSModel
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -92,11 +101,10 @@ func (d *tagOwnersDataSource) Read(ctx context.Context, req datasource.ReadReque
 	body, err := doTagOwnersDSRequest(ctx, d.httpClient, http.MethodGet, getURL, nil)
 	if err != nil {
 		if isNotFound(err) {
-			// If the server returns 404 => no data => do nothing
-			tflog.Warn(ctx, "No TagOwner found", map[string]interface{}{"name": name})
+			addDSMissingDiagnostic(&resp.Diagnostics, data.FailIfMissing, "TagOwner", fmt.Sprintf("named %q", name))
 			return
 		}
-		resp.Diagnostics.AddError("Read tagowner DS error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read tagowner DS error", err)
 		return
 	}
 
@@ -142,7 +150,7 @@ func doTagOwnersDSRequest(ctx context.Context, client *http.Client, method, url
 	}
 	if r.StatusCode >= 300 {
 		msg, _ := io.ReadAll(r.Body)
-		return nil, fmt.Errorf("TACL returned HTTP %d: %s", r.StatusCode, string(msg))
+		return nil, newAPIError(r, msg)
 	}
 
 	return io.ReadAll(r.Body)
@@ -0,0 +1,319 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure we match the Terraform Resource interfaces
+var (
+	_ resource.Resource                = &tagOwnersMapResource{}
+	_ resource.ResourceWithConfigure   = &tagOwnersMapResource{}
+	_ resource.ResourceWithImportState = &tagOwnersMapResource{}
+)
+
+// NewTagOwnersMapResource => constructor for "tacl_tag_owners" (plural)
+func NewTagOwnersMapResource() resource.Resource {
+	return &tagOwnersMapResource{}
+}
+
+// tagOwnersMapResource => manages the whole /tagowners map in one resource.
+// ID is always "tagowners".
+type tagOwnersMapResource struct {
+	httpClient *http.Client
+	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
+}
+
+// tagOwnersMapResourceModel => tag name => list of owners
+type tagOwnersMapResourceModel struct {
+	ID        types.String `tfsdk:"id"`         // always "tagowners"
+	TagOwners types.Map    `tfsdk:"tag_owners"` // map string => list string
+}
+
+func (r *tagOwnersMapResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p, ok := req.ProviderData.(*taclProvider)
+	if !ok {
+		return
+	}
+	r.httpClient = p.httpClient
+	r.endpoint = p.endpoint
+	r.logMasks = p.logMasks
+}
+
+func (r *tagOwnersMapResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	// e.g. "tacl_tag_owners"
+	resp.TypeName = req.ProviderTypeName + "_tag_owners"
+}
+
+func (r *tagOwnersMapResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the full TagOwners map in TACL’s /tagowners, written with a single PUT of the whole map. " +
+			"Tags not listed here are removed, so don't combine this with tacl_tag_owner resources. " +
+			"Import with the ID 'tagowners'.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Always 'tagowners' once created.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tag_owners": schema.MapAttribute{
+				Description: "Map of tag name => list of owners.",
+				Required:    true,
+				ElementType: types.ListType{ElemType: types.StringType},
+			},
+		},
+	}
+}
+
+// --------------------------------------------------------------------------------
+// Create => PUT /tagowners with the whole map
+// --------------------------------------------------------------------------------
+
+func (r *tagOwnersMapResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var plan tagOwnersMapResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.replaceAll(ctx, toStringSliceMap(plan.TagOwners), &resp.Diagnostics); err != nil {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Create tagowners error", err)
+		return
+	}
+
+	final, err := r.fetchAll(ctx)
+	if err != nil {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read tagowners error", err)
+		return
+	}
+
+	plan.ID = types.StringValue("tagowners")
+	plan.TagOwners = toTerraformMapOfStringList(final)
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// --------------------------------------------------------------------------------
+// Read => GET /tagowners
+// --------------------------------------------------------------------------------
+
+func (r *tagOwnersMapResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var data tagOwnersMapResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fetched, err := r.fetchAll(ctx)
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read tagowners error", err)
+		return
+	}
+
+	data.ID = types.StringValue("tagowners")
+	data.TagOwners = toTerraformMapOfStringList(fetched)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// --------------------------------------------------------------------------------
+// Update => PUT /tagowners with the whole map
+// --------------------------------------------------------------------------------
+
+func (r *tagOwnersMapResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	var plan tagOwnersMapResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.replaceAll(ctx, toStringSliceMap(plan.TagOwners), &resp.Diagnostics); err != nil {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Update tagowners error", err)
+		return
+	}
+
+	final, err := r.fetchAll(ctx)
+	if err != nil {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read tagowners error", err)
+		return
+	}
+
+	plan.ID = types.StringValue("tagowners")
+	plan.TagOwners = toTerraformMapOfStringList(final)
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// --------------------------------------------------------------------------------
+// Delete => PUT /tagowners with an empty map
+// --------------------------------------------------------------------------------
+
+func (r *tagOwnersMapResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	if err := r.replaceAll(ctx, map[string][]string{}, &resp.Diagnostics); err != nil {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Delete tagowners error", err)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState => the map is a singleton; Read fills it in
+func (r *tagOwnersMapResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
+	if req.ID != "tagowners" {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("tacl_tag_owners is imported with the ID 'tagowners', got %q.", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), "tagowners")...)
+}
+
+// --------------------------------------------------------------------------------
+// Helpers
+// --------------------------------------------------------------------------------
+
+// fetchAll => GET /tagowners => name => owners
+func (r *tagOwnersMapResource) fetchAll(ctx context.Context) (map[string][]string, error) {
+	getURL := fmt.Sprintf("%s/tagowners", r.endpoint)
+	tflog.Debug(ctx, "Reading all TagOwners", map[string]interface{}{"url": getURL})
+
+	items, err := doListRequest(ctx, r.httpClient, getURL)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string][]string, len(items))
+	for _, raw := range items {
+		var to TagOwnerResponse
+		if e := json.Unmarshal(raw, &to); e != nil {
+			return nil, fmt.Errorf("parse tagowners list: %w", e)
+		}
+		out[to.Name] = to.Owners
+	}
+	return out, nil
+}
+
+// replaceAll => make the server's map exactly desired with one PUT
+// /tagowners of the whole map, so a failure can't leave it half-applied.
+// Servers without the bulk PUT (404/405/501) get the per-tag applyDiff
+// instead, with a warning that it isn't atomic.
+func (r *tagOwnersMapResource) replaceAll(ctx context.Context, desired map[string][]string, diags *diag.Diagnostics) error {
+	url := fmt.Sprintf("%s/tagowners", r.endpoint)
+	tflog.Debug(ctx, "Replacing TagOwners map", map[string]interface{}{
+		"url":     url,
+		"payload": redactForLog(desired),
+	})
+
+	_, err := doTagOwnersRequest(ctx, r.httpClient, http.MethodPut, url, desired)
+	var apiErr *APIError
+	if err == nil || !(isNotFound(err) || (errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusMethodNotAllowed || apiErr.StatusCode == http.StatusNotImplemented))) {
+		return err
+	}
+
+	diags.AddWarning("TagOwners map applied tag by tag",
+		"The TACL server has no bulk PUT /tagowners, so tags were written one request at a time. "+
+			"If a request fails part-way, the map is left partly applied until the next apply.")
+	current, err := r.fetchAll(ctx)
+	if err != nil {
+		return err
+	}
+	return r.applyDiff(ctx, current, desired)
+}
+
+// applyDiff => POST tags missing from current, PUT tags whose owners changed,
+// DELETE tags no longer desired. Tags are processed in sorted order.
+func (r *tagOwnersMapResource) applyDiff(ctx context.Context, current, desired map[string][]string) error {
+	url := fmt.Sprintf("%s/tagowners", r.endpoint)
+
+	var names []string
+	for name := range desired {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		owners := desired[name]
+		existing, ok := current[name]
+		if ok && equalStringSlice(existing, owners) {
+			continue
+		}
+
+		method := http.MethodPost
+		if ok {
+			method = http.MethodPut
+		}
+		payload := map[string]interface{}{
+			"name":   name,
+			"owners": owners,
+		}
+		tflog.Debug(ctx, "Applying TagOwner", map[string]interface{}{
+			"url":     url,
+			"method":  method,
+			"payload": redactForLog(payload),
+		})
+		if _, err := doTagOwnersRequest(ctx, r.httpClient, method, url, payload); err != nil {
+			return fmt.Errorf("tag %q: %w", name, err)
+		}
+	}
+
+	var stale []string
+	for name := range current {
+		if _, ok := desired[name]; !ok {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+
+	for _, name := range stale {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		tflog.Debug(ctx, "Deleting TagOwner", map[string]interface{}{
+			"url":  url,
+			"name": name,
+		})
+		_, err := doTagOwnersRequest(ctx, r.httpClient, http.MethodDelete, url, map[string]string{"name": name})
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf("tag %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func tagOwnersMapValue(m map[string][]string) tftypes.Value {
+	typ := tftypes.Map{ElementType: tftypes.List{ElementType: tftypes.String}}
+	vals := map[string]tftypes.Value{}
+	for tag, owners := range m {
+		vals[tag] = tfStrings(listOf, owners...)
+	}
+	return tftypes.NewValue(typ, vals)
+}
+
+func TestTagOwnersMapResource_SinglePUT(t *testing.T) {
+	srv := newFakeTACL(t)
+	r := newTestResource(t, newTestProvider(t, srv, nil), NewTagOwnersMapResource())
+
+	state, diags := r.create(map[string]tftypes.Value{
+		"tag_owners": tagOwnersMapValue(map[string][]string{
+			"tag:web": {"group:eng"},
+			"tag:db":  {"group:dba"},
+		}),
+	})
+	requireNoErrors(t, diags)
+	if n := srv.requested("PUT /tagowners"); n != 1 {
+		t.Fatalf("create sent %d PUT /tagowners, want 1", n)
+	}
+	if n := srv.requested("POST /tagowners") + srv.requested("DELETE /tagowners"); n != 0 {
+		t.Fatalf("create sent %d per-tag requests", n)
+	}
+
+	state, diags = r.update(state, map[string]tftypes.Value{
+		"tag_owners": tagOwnersMapValue(map[string][]string{"tag:web": {"group:eng"}}),
+	})
+	requireNoErrors(t, diags)
+	if _, ok := srv.named["tagowners"]["tag:db"]; ok || srv.requested("PUT /tagowners") != 2 {
+		t.Fatalf("update didn't replace the map in one PUT: %v", srv.named["tagowners"])
+	}
+
+	gone, diags := r.delete(state)
+	requireNoErrors(t, diags)
+	if !gone || len(srv.named["tagowners"]) != 0 {
+		t.Fatalf("delete left state=%v server=%v", !gone, srv.named["tagowners"])
+	}
+}
+
+func TestTagOwnersMapResource_FallbackWithoutBulkPUT(t *testing.T) {
+	srv := newFakeTACL(t)
+	srv.handle("PUT /tagowners", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	})
+	r := newTestResource(t, newTestProvider(t, srv, nil), NewTagOwnersMapResource())
+
+	_, diags := r.create(map[string]tftypes.Value{
+		"tag_owners": tagOwnersMapValue(map[string][]string{"tag:web": {"group:eng"}}),
+	})
+	requireNoErrors(t, diags)
+	if len(diags.Warnings()) != 1 {
+		t.Fatalf("want one non-atomic warning, got %v", diags.Warnings())
+	}
+	if _, ok := srv.named["tagowners"]["tag:web"]; !ok {
+		t.Fatal("fallback didn't POST the tag")
+	}
+}
+
+func TestTagOwnersMapResource_ImportState(t *testing.T) {
+	r := newTestResource(t, &taclProvider{}, NewTagOwnersMapResource())
+	importer := r.r.(resource.ResourceWithImportState)
+
+	for id, wantErr := range map[string]bool{"tagowners": false, "tag:web": true} {
+		resp := resource.ImportStateResponse{State: r.emptyState()}
+		importer.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, &resp)
+		if resp.Diagnostics.HasError() != wantErr {
+			t.Errorf("import %q: errors = %v, want error %v", id, resp.Diagnostics.Errors(), wantErr)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -22,8 +23,9 @@ type TagOwnerResponse struct {
 
 // Ensure we match the Terraform Resource interfaces
 var (
-	_ resource.Resource              = &tagOwnersResource{}
-	_ resource.ResourceWithConfigure = &tagOwnersResource{}
+	_ resource.Resource                 = &tagOwnersResource{}
+	_ resource.ResourceWithConfigure    = &tagOwnersResource{}
+	_ resource.ResourceWithUpgradeState = &tagOwnersResource{}
 )
 
 func NewTagOwnersResource() resource.Resource {
@@ -33,13 +35,15 @@ func NewTagOwnersResource() resource.Resource {
 type tagOwnersResource struct {
 	httpClient *http.Client
 	endpoint   string
+	logMasks   []string // provider secrets, masked in tflog (see maskLogCtx)
 }
 
 // tagOwnersResourceModel => user sets name + owners, we store ID same as name
 type tagOwnersResourceModel struct {
-	ID     types.String   `tfsdk:"id"`     // same as "name"
-	Name   types.String   `tfsdk:"name"`   // required
-	Owners []types.String `tfsdk:"owners"` // required
+	ID       types.String   `tfsdk:"id"`        // same as "name"
+	ServerID types.String   `tfsdk:"server_id"` // the server's own ID, if it reports one
+	Name     types.String   `tfsdk:"name"`      // required
+	Owners   []types.String `tfsdk:"owners"`    // required, a set => order doesn't matter
 }
 
 func (r *tagOwnersResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -52,6 +56,7 @@ func (r *tagOwnersResource) Configure(ctx context.Context, req resource.Configur
 	}
 	r.httpClient = p.httpClient
 	r.endpoint = p.endpoint
+	r.logMasks = p.logMasks
 }
 
 func (r *tagOwnersResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -62,17 +67,29 @@ func (r *tagOwnersResource) Metadata(ctx context.Context, req resource.MetadataR
 func (r *tagOwnersResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a single TagOwner by name in TACL’s /tagowners.",
+		// v1 => server_id holds the server's ID; id stays the name
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Same as 'name' once created.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					nameIDPlanModifier{},
+				},
+			},
+			"server_id": schema.StringAttribute{
+				Description: "ID TACL reports for the tag owner, e.g. a UUID on newer servers. Null for servers that key them by name only.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					nameIDPlanModifier{},
+				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The unique tag name (e.g. 'webserver').",
 				Required:    true,
 			},
-			"owners": schema.ListAttribute{
-				Description: "List of owners for this tag.",
+			"owners": schema.SetAttribute{
+				Description: "Set of owners for this tag. Order doesn't matter.",
 				Required:    true,
 				ElementType: types.StringType,
 			},
@@ -80,11 +97,57 @@ func (r *tagOwnersResource) Schema(ctx context.Context, req resource.SchemaReque
 	}
 }
 
+// tagOwnersResourceModelV0 => tacl_tag_owner state as written by schema version 0
+type tagOwnersResourceModelV0 struct {
+	ID     types.String   `tfsdk:"id"`
+	Name   types.String   `tfsdk:"name"`
+	Owners []types.String `tfsdk:"owners"`
+}
+
+// tagOwnersResourceSchemaV0 => tacl_tag_owner schema version 0, frozen; don't edit it along
+// with Schema.
+func tagOwnersResourceSchemaV0() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":     schema.StringAttribute{Computed: true},
+			"name":   schema.StringAttribute{Required: true},
+			"owners": schema.SetAttribute{Required: true, ElementType: types.StringType},
+		},
+	}
+}
+
+// UpgradeState => v0 had no server_id. Re-read by name so state picks up the
+// server's ID (a UUID on newer TACL); id stays the name.
+func (r *tagOwnersResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	priorSchema := tagOwnersResourceSchemaV0()
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior tagOwnersResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				name := prior.Name.ValueString()
+				data := tagOwnersResourceModel{
+					ID:       types.StringValue(name),
+					ServerID: upgradeServerID(ctx, r.httpClient, fmt.Sprintf("%s/tagowners/%s", r.endpoint, name), name),
+					Name:     prior.Name,
+					Owners:   prior.Owners,
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
 // --------------------------------------------------------------------------------
 // Create => POST /tagowners => { name, owners }
 // --------------------------------------------------------------------------------
 
 func (r *tagOwnersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var plan tagOwnersResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -100,12 +163,12 @@ func (r *tagOwnersResource) Create(ctx context.Context, req resource.CreateReque
 	postURL := fmt.Sprintf("%s/tagowners", r.endpoint)
 	tflog.Debug(ctx, "Creating TagOwner", map[string]interface{}{
 		"url":     postURL,
-		"payload": payload,
+		"payload": redactForLog(payload),
 	})
 
 	body, err := doTagOwnersRequest(ctx, r.httpClient, http.MethodPost, postURL, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Create tagowner error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Create tagowner error", err)
 		return
 	}
 
@@ -115,8 +178,8 @@ func (r *tagOwnersResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	// set ID => name
 	plan.ID = types.StringValue(created.Name)
+	plan.ServerID = serverObjectID(body)
 	plan.Name = types.StringValue(created.Name)
 	plan.Owners = toTerraformStringSlice(created.Owners)
 
@@ -129,6 +192,7 @@ func (r *tagOwnersResource) Create(ctx context.Context, req resource.CreateReque
 // --------------------------------------------------------------------------------
 
 func (r *tagOwnersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data tagOwnersResourceModel
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -155,7 +219,7 @@ func (r *tagOwnersResource) Read(ctx context.Context, req resource.ReadRequest,
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Read tagowner error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Read tagowner error", err)
 		return
 	}
 
@@ -166,6 +230,7 @@ func (r *tagOwnersResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	data.ID = types.StringValue(fetched.Name)
+	data.ServerID = serverObjectID(body)
 	data.Name = types.StringValue(fetched.Name)
 	data.Owners = toTerraformStringSlice(fetched.Owners)
 
@@ -178,6 +243,7 @@ func (r *tagOwnersResource) Read(ctx context.Context, req resource.ReadRequest,
 // --------------------------------------------------------------------------------
 
 func (r *tagOwnersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var oldState tagOwnersResourceModel
 	diags := req.State.Get(ctx, &oldState)
 	resp.Diagnostics.Append(diags...)
@@ -209,7 +275,7 @@ func (r *tagOwnersResource) Update(ctx context.Context, req resource.UpdateReque
 	putURL := fmt.Sprintf("%s/tagowners", r.endpoint)
 	tflog.Debug(ctx, "Updating TagOwner by name", map[string]interface{}{
 		"url":     putURL,
-		"payload": payload,
+		"payload": redactForLog(payload),
 	})
 
 	body, err := doTagOwnersRequest(ctx, r.httpClient, http.MethodPut, putURL, payload)
@@ -219,7 +285,7 @@ func (r *tagOwnersResource) Update(ctx context.Context, req resource.UpdateReque
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Update tagowner error", err.Error())
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Update tagowner error", err)
 		return
 	}
 
@@ -230,6 +296,7 @@ func (r *tagOwnersResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	plan.ID = types.StringValue(updated.Name)
+	plan.ServerID = serverObjectID(body)
 	plan.Name = types.StringValue(updated.Name)
 	plan.Owners = toTerraformStringSlice(updated.Owners)
 
@@ -242,6 +309,7 @@ func (r *tagOwnersResource) Update(ctx context.Context, req resource.UpdateReque
 // --------------------------------------------------------------------------------
 
 func (r *tagOwnersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = maskLogCtx(ctx, r.logMasks)
 	var data tagOwnersResourceModel
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -263,13 +331,9 @@ func (r *tagOwnersResource) Delete(ctx context.Context, req resource.DeleteReque
 	})
 
 	_, err := doTagOwnersRequest(ctx, r.httpClient, http.MethodDelete, delURL, delPayload)
-	if err != nil {
-		if isNotFound(err) {
-			// already gone
-		} else {
-			resp.Diagnostics.AddError("Delete tagowner error", err.Error())
-			return
-		}
+	if err != nil && !isNotFound(err) {
+		addAPIErrorDiagnostic(&resp.Diagnostics, "Delete tagowner error", err)
+		return
 	}
 
 	resp.State.RemoveResource(ctx)
@@ -306,7 +370,7 @@ func doTagOwnersRequest(ctx context.Context, client *http.Client, method, url st
 	}
 	if respHTTP.StatusCode >= 300 {
 		msg, _ := io.ReadAll(respHTTP.Body)
-		return nil, fmt.Errorf("TACL returned %d: %s", respHTTP.StatusCode, string(msg))
+		return nil, newAPIError(respHTTP, msg)
 	}
 
 	return io.ReadAll(respHTTP.Body)
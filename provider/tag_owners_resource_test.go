@@ -0,0 +1,13 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTagOwnersResource_UpgradeStateV0(t *testing.T) {
+	testNameIDUpgrade(t, NewTagOwnersResource, "tagowners", map[string]tftypes.Value{
+		"owners": tfStrings(setOf, "group:eng"),
+	})
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure DS compliance
+var (
+	_ datasource.DataSource              = &tailnetInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &tailnetInfoDataSource{}
+)
+
+// NewTailnetInfoDataSource => "tacl_tailnet_info" data source
+func NewTailnetInfoDataSource() datasource.DataSource {
+	return &tailnetInfoDataSource{}
+}
+
+type tailnetInfoDataSource struct {
+	httpClient  *http.Client
+	endpoint    string
+	logMasks    []string // provider secrets, masked in tflog (see maskLogCtx)
+	tailnetName string   // provider's tailnet_name
+}
+
+type tailnetInfoDSModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	DNSSuffix      types.String `tfsdk:"dns_suffix"`
+	DeviceCount    types.Int64  `tfsdk:"device_count"`
+	Endpoint       types.String `tfsdk:"endpoint"`
+	ServerReported types.Bool   `tfsdk:"server_reported"`
+}
+
+// taclTailnetInfo => GET /tailnet. Versions differ on the suffix key.
+type taclTailnetInfo struct {
+	Name           string `json:"name"`
+	DNSSuffix      string `json:"dnsSuffix"`
+	MagicDNSSuffix string `json:"magicDNSSuffix"`
+	DeviceCount    *int64 `json:"deviceCount"`
+}
+
+func (d *tailnetInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p, ok := req.ProviderData.(*taclProvider)
+	if !ok {
+		return
+	}
+	d.httpClient = p.dsHTTPClient
+	d.endpoint = p.endpoint
+	d.logMasks = p.logMasks
+	d.tailnetName = p.tailnetName
+}
+
+func (d *tailnetInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tailnet_info"
+}
+
+func (d *tailnetInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Basic tailnet metadata from TACL's /tailnet. If the server has no such endpoint, " +
+			"falls back to the provider's configured tailnet_name and endpoint.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Always 'tailnet'.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Tailnet name as reported by TACL, else the provider's tailnet_name. Null if neither is available.",
+				Computed:    true,
+			},
+			"dns_suffix": schema.StringAttribute{
+				Description: "MagicDNS suffix of the tailnet (e.g. 'tail1234.ts.net'), if TACL reports it.",
+				Computed:    true,
+			},
+			"device_count": schema.Int64Attribute{
+				Description: "Number of devices in the tailnet, if TACL reports it.",
+				Computed:    true,
+			},
+			"endpoint": schema.StringAttribute{
+				Description: "TACL endpoint the provider is configured with.",
+				Computed:    true,
+			},
+			"server_reported": schema.BoolAttribute{
+				Description: "True if the values came from TACL's /tailnet, false if only the provider configuration was used.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *tailnetInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = maskLogCtx(ctx, d.logMasks)
+	var data tailnetInfoDSModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue("tailnet")
+	data.Name = stringOrNull(d.tailnetName)
+	data.DNSSuffix = types.StringNull()
+	data.DeviceCount = types.Int64Null()
+	data.Endpoint = types.StringValue(d.endpoint)
+	data.ServerReported = types.BoolValue(false)
+
+	getURL := fmt.Sprintf("%s/tailnet", d.endpoint)
+	tflog.Debug(ctx, "Reading tailnet info", map[string]interface{}{"url": getURL})
+
+	body, err := doSingleObjectReq(ctx, d.httpClient, http.MethodGet, getURL, nil)
+	if err != nil {
+		if !isNotFound(err) {
+			addAPIErrorDiagnostic(&resp.Diagnostics, "Read tailnet info error", err)
+			return
+		}
+		// older TACL => configured values only
+		tflog.Debug(ctx, "TACL has no /tailnet endpoint, using provider configuration", nil)
+	} else {
+		var info taclTailnetInfo
+		if e := json.Unmarshal(body, &info); e != nil {
+			resp.Diagnostics.AddError("Parse tailnet info error", e.Error())
+			return
+		}
+		if info.Name != "" {
+			data.Name = types.StringValue(info.Name)
+		}
+		if info.DNSSuffix != "" {
+			data.DNSSuffix = types.StringValue(info.DNSSuffix)
+		} else {
+			data.DNSSuffix = stringOrNull(info.MagicDNSSuffix)
+		}
+		if info.DeviceCount != nil {
+			data.DeviceCount = types.Int64Value(*info.DeviceCount)
+		}
+		data.ServerReported = types.BoolValue(true)
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
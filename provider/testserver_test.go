@@ -0,0 +1,841 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// fakeTACL => an in-memory TACL served over httptest, for tests.
+//
+//   - name-keyed collections (groups, hosts, tagowners, postures):
+//     POST/PUT/DELETE with { "name": ... } bodies, GET /<c> and GET
+//     /<c>/<name>. A PUT /tagowners without "name" replaces the whole map.
+//   - ID-keyed collections (acls, ssh, nodeattrs, grants, tests): POST the
+//     object, PUT { "id", "<wrapper>": {...} }, DELETE { "id" }, GET /<c> and
+//     /<c>/<id>. ACLs carry an ETag and honor If-Match.
+//   - singletons (settings, autoapprovers, postures/default): GET, POST or
+//     PUT the whole object, DELETE it.
+//   - /derpmap: a singleton; with derpMerge, PUT merges regions like some
+//     TACL versions do.
+//   - /policy: the raw HuJSON document, GET and PUT.
+//
+// handle() overrides any "METHOD /path" route, and every request is recorded
+// in requests.
+type fakeTACL struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	named     map[string]map[string]map[string]interface{} // collection => name => object
+	byID      map[string][]map[string]interface{}          // collection => objects, in insert order
+	etags     map[string]int                               // ACL id => version
+	singleton map[string]map[string]interface{}            // path => object
+	derpmap   map[string]interface{}
+	derpMerge bool
+	policy    []byte // nil => 404
+	nextID    int
+	overrides map[string]http.HandlerFunc
+	requests  []string // "METHOD /path"
+}
+
+// namedValueKeys => the value field of each name-keyed collection, for bulk PUTs
+var namedValueKeys = map[string]string{
+	"groups":    "members",
+	"hosts":     "ip",
+	"tagowners": "owners",
+	"postures":  "rules",
+}
+
+// idWrappers => the key PUT bodies wrap each ID-keyed object in
+var idWrappers = map[string]string{
+	"acls":      "entry",
+	"ssh":       "rule",
+	"nodeattrs": "grant",
+	"grants":    "grant",
+	"tests":     "test",
+}
+
+// singletonPaths => paths holding a single whole object
+var singletonPaths = map[string]bool{
+	"settings":         true,
+	"autoapprovers":    true,
+	"postures/default": true,
+}
+
+func newFakeTACL(t *testing.T) *fakeTACL {
+	t.Helper()
+	f := &fakeTACL{
+		named:     map[string]map[string]map[string]interface{}{},
+		byID:      map[string][]map[string]interface{}{},
+		etags:     map[string]int{},
+		singleton: map[string]map[string]interface{}{},
+		overrides: map[string]http.HandlerFunc{},
+	}
+	for c := range namedValueKeys {
+		f.named[c] = map[string]map[string]interface{}{}
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// handle => serve "METHOD /path" with h instead of the in-memory store
+func (f *fakeTACL) handle(route string, h http.HandlerFunc) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.overrides[route] = h
+}
+
+// requested => how many requests matched "METHOD /path"
+func (f *fakeTACL) requested(route string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, r := range f.requests {
+		if r == route {
+			n++
+		}
+	}
+	return n
+}
+
+func (f *fakeTACL) serve(w http.ResponseWriter, r *http.Request) {
+	route := r.Method + " " + r.URL.Path
+	f.mu.Lock()
+	f.requests = append(f.requests, route)
+	h := f.overrides[route]
+	f.mu.Unlock()
+	if h != nil {
+		h(w, r)
+		return
+	}
+
+	if r.URL.Path == "/policy" {
+		f.servePolicy(w, r)
+		return
+	}
+
+	var body map[string]interface{}
+	if r.Body != nil && r.Method != http.MethodGet {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err.Error() != "EOF" {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] == "health":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "ok"})
+	case singletonPaths[strings.Join(parts, "/")]:
+		f.serveSingleton(w, r, strings.Join(parts, "/"), body)
+	case parts[0] == "derpmap":
+		f.serveDERPMap(w, r, body)
+	case f.named[parts[0]] != nil:
+		f.serveNamed(w, r, parts, body)
+	case idWrappers[parts[0]] != "":
+		f.serveByID(w, r, parts, body)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeTACL) serveNamed(w http.ResponseWriter, r *http.Request, parts []string, body map[string]interface{}) {
+	coll := f.named[parts[0]]
+	if len(parts) == 2 {
+		obj, ok := coll[parts[1]]
+		if r.Method != http.MethodGet || !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, http.StatusOK, obj)
+		return
+	}
+
+	name, _ := body["name"].(string)
+	switch r.Method {
+	case http.MethodGet:
+		names := make([]string, 0, len(coll))
+		for n := range coll {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		list := make([]interface{}, 0, len(names))
+		for _, n := range names {
+			list = append(list, coll[n])
+		}
+		writeJSON(w, http.StatusOK, list)
+	case http.MethodPost:
+		if _, ok := coll[name]; ok {
+			http.Error(w, "already exists", http.StatusConflict)
+			return
+		}
+		coll[name] = body
+		writeJSON(w, http.StatusOK, body)
+	case http.MethodPut:
+		if name == "" {
+			// whole-map replace, e.g. PUT /tagowners { "tag:x": [...] }
+			valueKey := namedValueKeys[parts[0]]
+			for n := range coll {
+				delete(coll, n)
+			}
+			for n, v := range body {
+				coll[n] = map[string]interface{}{"name": n, valueKey: v}
+			}
+			writeJSON(w, http.StatusOK, body)
+			return
+		}
+		if _, ok := coll[name]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+		coll[name] = body
+		writeJSON(w, http.StatusOK, body)
+	case http.MethodDelete:
+		if _, ok := coll[name]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+		delete(coll, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeTACL) serveByID(w http.ResponseWriter, r *http.Request, parts []string, body map[string]interface{}) {
+	name := parts[0]
+	find := func(id string) int {
+		for i, obj := range f.byID[name] {
+			if obj["id"] == id {
+				return i
+			}
+		}
+		return -1
+	}
+	writeObj := func(status int, obj map[string]interface{}) {
+		if name == "acls" {
+			w.Header().Set("ETag", fmt.Sprintf(`"v%d"`, f.etags[obj["id"].(string)]))
+		}
+		writeJSON(w, status, obj)
+	}
+	// If-Match => 412 unless it names the ACL's current version
+	ifMatchOK := func(id string) bool {
+		m := r.Header.Get("If-Match")
+		return name != "acls" || m == "" || m == fmt.Sprintf(`"v%d"`, f.etags[id])
+	}
+
+	if len(parts) == 2 {
+		i := find(parts[1])
+		if r.Method != http.MethodGet || i < 0 {
+			http.NotFound(w, r)
+			return
+		}
+		writeObj(http.StatusOK, f.byID[name][i])
+		return
+	}
+
+	id, _ := body["id"].(string)
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, f.byID[name])
+	case http.MethodPost:
+		f.nextID++
+		body["id"] = fmt.Sprintf("%s-%d", name, f.nextID)
+		f.byID[name] = append(f.byID[name], body)
+		f.etags[body["id"].(string)] = 1
+		writeObj(http.StatusOK, body)
+	case http.MethodPut:
+		i := find(id)
+		if i < 0 {
+			http.NotFound(w, r)
+			return
+		}
+		if !ifMatchOK(id) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		obj, _ := body[idWrappers[name]].(map[string]interface{})
+		if obj == nil {
+			http.Error(w, "missing "+idWrappers[name], http.StatusBadRequest)
+			return
+		}
+		obj["id"] = id
+		f.byID[name][i] = obj
+		f.etags[id]++
+		writeObj(http.StatusOK, obj)
+	case http.MethodDelete:
+		i := find(id)
+		if i < 0 {
+			http.NotFound(w, r)
+			return
+		}
+		if !ifMatchOK(id) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		f.byID[name] = append(f.byID[name][:i], f.byID[name][i+1:]...)
+		delete(f.etags, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeTACL) serveDERPMap(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+	switch r.Method {
+	case http.MethodGet:
+		if f.derpmap == nil {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, http.StatusOK, f.derpmap)
+	case http.MethodPost:
+		f.derpmap = body
+		writeJSON(w, http.StatusOK, f.derpmap)
+	case http.MethodPut:
+		if f.derpmap == nil {
+			http.NotFound(w, r)
+			return
+		}
+		if f.derpMerge {
+			regions, _ := f.derpmap["regions"].(map[string]interface{})
+			if regions == nil {
+				regions = map[string]interface{}{}
+			}
+			newRegions, _ := body["regions"].(map[string]interface{})
+			for id, region := range newRegions {
+				regions[id] = region
+			}
+			body["regions"] = regions
+		}
+		f.derpmap = body
+		writeJSON(w, http.StatusOK, f.derpmap)
+	case http.MethodDelete:
+		if f.derpmap == nil {
+			http.NotFound(w, r)
+			return
+		}
+		f.derpmap = nil
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeTACL) serveSingleton(w http.ResponseWriter, r *http.Request, path string, body map[string]interface{}) {
+	switch r.Method {
+	case http.MethodGet:
+		obj, ok := f.singleton[path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodPost, http.MethodPut:
+		if body == nil {
+			body = map[string]interface{}{}
+		}
+		f.singleton[path] = body
+		writeJSON(w, http.StatusOK, body)
+	case http.MethodDelete:
+		if _, ok := f.singleton[path]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+		delete(f.singleton, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeTACL) servePolicy(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodGet:
+		if f.policy == nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/hujson")
+		_, _ = w.Write(f.policy)
+	case http.MethodPut:
+		doc, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.policy = doc
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// testAccProtoV6ProviderFactories => for acceptance tests (TF_ACC=1)
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"tacl": providerserver.NewProtocol6WithError(New()),
+}
+
+// ------------------------------------------------------------------------------
+// Unit harness: drive provider, resource and data source methods directly,
+// without a Terraform binary.
+// ------------------------------------------------------------------------------
+
+// newTestProvider => a taclProvider configured against srv through the real
+// Configure (and so the real transport chain). config sets provider
+// attributes; max_retries defaults to 0 so error paths fail fast.
+func newTestProvider(t *testing.T, srv *fakeTACL, config map[string]tftypes.Value) *taclProvider {
+	t.Helper()
+	ctx := context.Background()
+	p := New().(*taclProvider)
+
+	var schemaResp fwprovider.SchemaResponse
+	p.Schema(ctx, fwprovider.SchemaRequest{}, &schemaResp)
+
+	vals := map[string]tftypes.Value{
+		"endpoint":    tftypes.NewValue(tftypes.String, srv.URL),
+		"max_retries": tftypes.NewValue(tftypes.Number, 0),
+	}
+	for k, v := range config {
+		vals[k] = v
+	}
+	raw := objectValue(t, schemaResp.Schema.Type().TerraformType(ctx), vals, nil)
+
+	resp := fwprovider.ConfigureResponse{}
+	p.Configure(ctx, fwprovider.ConfigureRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw},
+	}, &resp)
+	requireNoErrors(t, resp.Diagnostics)
+	return p
+}
+
+// objectValue => an object of typ with vals set and every other attribute
+// filled by missing (null when missing is nil)
+func objectValue(t *testing.T, typ tftypes.Type, vals map[string]tftypes.Value, missing func(name string, typ tftypes.Type) tftypes.Value) tftypes.Value {
+	t.Helper()
+	objType, ok := typ.(tftypes.Object)
+	if !ok {
+		t.Fatalf("schema type is %T, not an object", typ)
+	}
+	attrs := make(map[string]tftypes.Value, len(objType.AttributeTypes))
+	for name, attrType := range objType.AttributeTypes {
+		switch v, ok := vals[name]; {
+		case ok:
+			attrs[name] = v
+		case missing != nil:
+			attrs[name] = missing(name, attrType)
+		default:
+			attrs[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+	for name := range vals {
+		if _, ok := objType.AttributeTypes[name]; !ok {
+			t.Fatalf("unknown attribute %q", name)
+		}
+	}
+	return tftypes.NewValue(objType, attrs)
+}
+
+// testResource => r configured with p, plus its schema
+type testResource struct {
+	t      *testing.T
+	r      resource.Resource
+	schema rschema.Schema
+}
+
+func newTestResource(t *testing.T, p *taclProvider, r resource.Resource) *testResource {
+	t.Helper()
+	ctx := context.Background()
+	if rc, ok := r.(resource.ResourceWithConfigure); ok {
+		var resp resource.ConfigureResponse
+		rc.Configure(ctx, resource.ConfigureRequest{ProviderData: p}, &resp)
+		requireNoErrors(t, resp.Diagnostics)
+	}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	requireNoErrors(t, schemaResp.Diagnostics)
+	return &testResource{t: t, r: r, schema: schemaResp.Schema}
+}
+
+// values => native Go values (see tfValue) as tftypes values for tr's
+// attributes, for create, update and friends
+func (tr *testResource) values(native map[string]interface{}) map[string]tftypes.Value {
+	tr.t.Helper()
+	attrTypes := tr.schema.Type().TerraformType(context.Background()).(tftypes.Object).AttributeTypes
+	vals := make(map[string]tftypes.Value, len(native))
+	for k, v := range native {
+		typ, ok := attrTypes[k]
+		if !ok {
+			tr.t.Fatalf("unknown attribute %q", k)
+		}
+		vals[k] = tfValue(tr.t, typ, v)
+	}
+	return vals
+}
+
+// config => configuration with vals set and everything else null
+func (tr *testResource) config(vals map[string]tftypes.Value) tfsdk.Config {
+	return tfsdk.Config{Schema: tr.schema, Raw: objectValue(tr.t, tr.schema.Type().TerraformType(context.Background()), vals, nil)}
+}
+
+// validate => ValidateConfig with config vals
+func (tr *testResource) validate(vals map[string]tftypes.Value) diag.Diagnostics {
+	tr.t.Helper()
+	v, ok := tr.r.(resource.ResourceWithValidateConfig)
+	if !ok {
+		tr.t.Fatalf("%T has no ValidateConfig", tr.r)
+	}
+	var resp resource.ValidateConfigResponse
+	v.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: tr.config(vals)}, &resp)
+	return resp.Diagnostics
+}
+
+// plan => vals as Terraform would plan them on create: unset computed
+// attributes are unknown, or their default when they have one
+func (tr *testResource) plan(vals map[string]tftypes.Value) tfsdk.Plan {
+	ctx := context.Background()
+	raw := objectValue(tr.t, tr.schema.Type().TerraformType(ctx), vals, func(name string, typ tftypes.Type) tftypes.Value {
+		attr, ok := tr.schema.Attributes[name]
+		if !ok || !attr.IsComputed() {
+			return tftypes.NewValue(typ, nil)
+		}
+		if v, ok := attributeDefault(ctx, attr); ok {
+			return v
+		}
+		return tftypes.NewValue(typ, tftypes.UnknownValue)
+	})
+	return tfsdk.Plan{Schema: tr.schema, Raw: raw}
+}
+
+// attributeDefault => the attribute's schema default, if it has one
+func attributeDefault(ctx context.Context, attr rschema.Attribute) (tftypes.Value, bool) {
+	switch a := attr.(type) {
+	case rschema.BoolAttribute:
+		if a.Default != nil {
+			var resp defaults.BoolResponse
+			a.Default.DefaultBool(ctx, defaults.BoolRequest{}, &resp)
+			v, _ := resp.PlanValue.ToTerraformValue(ctx)
+			return v, true
+		}
+	case rschema.StringAttribute:
+		if a.Default != nil {
+			var resp defaults.StringResponse
+			a.Default.DefaultString(ctx, defaults.StringRequest{}, &resp)
+			v, _ := resp.PlanValue.ToTerraformValue(ctx)
+			return v, true
+		}
+	case rschema.Int64Attribute:
+		if a.Default != nil {
+			var resp defaults.Int64Response
+			a.Default.DefaultInt64(ctx, defaults.Int64Request{}, &resp)
+			v, _ := resp.PlanValue.ToTerraformValue(ctx)
+			return v, true
+		}
+	}
+	return tftypes.Value{}, false
+}
+
+func (tr *testResource) emptyState() tfsdk.State {
+	typ := tr.schema.Type().TerraformType(context.Background())
+	return tfsdk.State{Schema: tr.schema, Raw: tftypes.NewValue(typ, nil)}
+}
+
+// create => Create with config vals; returns the new state
+func (tr *testResource) create(vals map[string]tftypes.Value) (tfsdk.State, diag.Diagnostics) {
+	resp := resource.CreateResponse{State: tr.emptyState()}
+	tr.r.Create(context.Background(), resource.CreateRequest{
+		Config: tr.config(vals),
+		Plan:   tr.plan(vals),
+	}, &resp)
+	return resp.State, resp.Diagnostics
+}
+
+// read => Read (refresh) state
+func (tr *testResource) read(state tfsdk.State) (tfsdk.State, diag.Diagnostics) {
+	resp := resource.ReadResponse{State: state}
+	tr.r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+	return resp.State, resp.Diagnostics
+}
+
+// update => Update prior state to config vals. Unset computed attributes
+// are planned from prior state, as UseStateForUnknown would.
+func (tr *testResource) update(prior tfsdk.State, vals map[string]tftypes.Value) (tfsdk.State, diag.Diagnostics) {
+	ctx := context.Background()
+	var priorAttrs map[string]tftypes.Value
+	if err := prior.Raw.As(&priorAttrs); err != nil {
+		tr.t.Fatalf("prior state: %s", err)
+	}
+	planned := map[string]tftypes.Value{}
+	for name, attr := range tr.schema.Attributes {
+		if _, set := vals[name]; !set && attr.IsComputed() {
+			planned[name] = priorAttrs[name]
+		}
+	}
+	for k, v := range vals {
+		planned[k] = v
+	}
+	plan := tfsdk.Plan{Schema: tr.schema, Raw: objectValue(tr.t, tr.schema.Type().TerraformType(ctx), planned, nil)}
+
+	resp := resource.UpdateResponse{State: prior}
+	tr.r.Update(ctx, resource.UpdateRequest{
+		Config: tr.config(vals),
+		Plan:   plan,
+		State:  prior,
+	}, &resp)
+	return resp.State, resp.Diagnostics
+}
+
+// delete => Delete; returns whether the resource left state
+func (tr *testResource) delete(state tfsdk.State) (bool, diag.Diagnostics) {
+	resp := resource.DeleteResponse{State: state}
+	tr.r.Delete(context.Background(), resource.DeleteRequest{State: state}, &resp)
+	return resp.State.Raw.IsNull(), resp.Diagnostics
+}
+
+// upgradeState => run r's state upgrader from version on prior state vals
+// (other attributes null); returns the upgraded state
+func (tr *testResource) upgradeState(version int64, vals map[string]tftypes.Value) (tfsdk.State, diag.Diagnostics) {
+	tr.t.Helper()
+	ctx := context.Background()
+	upgrader, ok := tr.r.(resource.ResourceWithUpgradeState)
+	if !ok {
+		tr.t.Fatalf("%T has no state upgraders", tr.r)
+	}
+	u, ok := upgrader.UpgradeState(ctx)[version]
+	if !ok || u.PriorSchema == nil {
+		tr.t.Fatalf("no upgrader with a prior schema from v%d", version)
+	}
+	prior := tfsdk.State{Schema: *u.PriorSchema, Raw: objectValue(tr.t, u.PriorSchema.Type().TerraformType(ctx), vals, nil)}
+
+	resp := resource.UpgradeStateResponse{State: tr.emptyState()}
+	u.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &prior}, &resp)
+	return resp.State, resp.Diagnostics
+}
+
+// testNameIDUpgrade => the v0 upgrader of a name-addressed resource sets id
+// to the name (whatever v0 stored) and server_id from a re-read of
+// /<collection>/<name>; vals are the rest of the v0 state.
+func testNameIDUpgrade(t *testing.T, newResource func() resource.Resource, collection string, vals map[string]tftypes.Value) {
+	tests := []struct {
+		name         string
+		configured   bool
+		serverObj    map[string]interface{} // nil => not on the server
+		wantServerID string                 // "" => null
+	}{
+		{name: "server id", configured: true, serverObj: map[string]interface{}{"id": "5f0c"}, wantServerID: "5f0c"},
+		{name: "no server id", configured: true, serverObj: map[string]interface{}{}},
+		{name: "missing on server", configured: true},
+		{name: "provider not configured"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &taclProvider{}
+			if tt.configured {
+				srv := newFakeTACL(t)
+				if tt.serverObj != nil {
+					tt.serverObj["name"] = "eng"
+					srv.named[collection]["eng"] = tt.serverObj
+				}
+				p = newTestProvider(t, srv, nil)
+			}
+			r := newTestResource(t, p, newResource())
+
+			prior := map[string]tftypes.Value{"id": tfString("old-id"), "name": tfString("eng")}
+			for k, v := range vals {
+				prior[k] = v
+			}
+			state, diags := r.upgradeState(0, prior)
+			requireNoErrors(t, diags)
+			if got := stateString(t, state, "id"); got != "eng" {
+				t.Errorf("id = %q, want the name", got)
+			}
+			if got := stateString(t, state, "server_id"); got != tt.wantServerID || stateIsNull(t, state, "server_id") != (tt.wantServerID == "") {
+				t.Errorf("server_id = %q (null %v), want %q", got, stateIsNull(t, state, "server_id"), tt.wantServerID)
+			}
+		})
+	}
+}
+
+// validateResourceConfig => run typeName's full config validation (schema
+// validators included) through the provider server, as terraform validate
+// does; vals are set on a config that is otherwise null
+func validateResourceConfig(t *testing.T, typeName string, r resource.Resource, vals map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	t.Helper()
+	ctx := context.Background()
+	typ := newTestResource(t, &taclProvider{}, r).schema.Type().TerraformType(ctx)
+	config, err := tfprotov6.NewDynamicValue(typ, objectValue(t, typ, vals, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := testAccProtoV6ProviderFactories["tacl"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{TypeName: typeName, Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Diagnostics
+}
+
+// readDataSource => configure d with p and Read it with config vals
+func readDataSource(t *testing.T, p *taclProvider, d datasource.DataSource, vals map[string]tftypes.Value) (tfsdk.State, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+	if dc, ok := d.(datasource.DataSourceWithConfigure); ok {
+		var resp datasource.ConfigureResponse
+		dc.Configure(ctx, datasource.ConfigureRequest{ProviderData: p}, &resp)
+		requireNoErrors(t, resp.Diagnostics)
+	}
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	typ := schemaResp.Schema.Type().TerraformType(ctx)
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: objectValue(t, typ, vals, nil)}
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(typ, nil)}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+	return resp.State, resp.Diagnostics
+}
+
+// stateString => a string attribute of state ("" if null)
+func stateString(t *testing.T, state tfsdk.State, name string) string {
+	t.Helper()
+	var attrs map[string]tftypes.Value
+	if err := state.Raw.As(&attrs); err != nil {
+		t.Fatalf("state: %s", err)
+	}
+	var s *string
+	if err := attrs[name].As(&s); err != nil {
+		t.Fatalf("%s: %s", name, err)
+	}
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// stateIsNull => whether the attribute is null in state
+func stateIsNull(t *testing.T, state tfsdk.State, name string) bool {
+	t.Helper()
+	var attrs map[string]tftypes.Value
+	if err := state.Raw.As(&attrs); err != nil {
+		t.Fatalf("state: %s", err)
+	}
+	return attrs[name].IsNull()
+}
+
+func requireNoErrors(t *testing.T, diags diag.Diagnostics) {
+	t.Helper()
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags.Errors())
+	}
+}
+
+// tfValue => v as a value of typ: nil is null, a tftypes.Value is used
+// as-is, and strings, bools, ints, slices and maps nest to match typ (objects
+// leave missing attributes null)
+func tfValue(t *testing.T, typ tftypes.Type, v interface{}) tftypes.Value {
+	t.Helper()
+	switch v := v.(type) {
+	case nil:
+		return tftypes.NewValue(typ, nil)
+	case tftypes.Value:
+		return v
+	case string, bool:
+		return tftypes.NewValue(typ, v)
+	case int:
+		return tftypes.NewValue(typ, int64(v))
+	case []string:
+		elems := make([]interface{}, len(v))
+		for i, e := range v {
+			elems[i] = e
+		}
+		return tfValue(t, typ, elems)
+	case []interface{}:
+		var elemType tftypes.Type
+		switch c := typ.(type) {
+		case tftypes.List:
+			elemType = c.ElementType
+		case tftypes.Set:
+			elemType = c.ElementType
+		default:
+			t.Fatalf("%v can't hold a slice", typ)
+		}
+		elems := make([]tftypes.Value, len(v))
+		for i, e := range v {
+			elems[i] = tfValue(t, elemType, e)
+		}
+		return tftypes.NewValue(typ, elems)
+	case map[string]interface{}:
+		switch c := typ.(type) {
+		case tftypes.Map:
+			elems := make(map[string]tftypes.Value, len(v))
+			for k, e := range v {
+				elems[k] = tfValue(t, c.ElementType, e)
+			}
+			return tftypes.NewValue(typ, elems)
+		case tftypes.Object:
+			attrs := make(map[string]tftypes.Value, len(v))
+			for k, e := range v {
+				attrType, ok := c.AttributeTypes[k]
+				if !ok {
+					t.Fatalf("unknown attribute %q", k)
+				}
+				attrs[k] = tfValue(t, attrType, e)
+			}
+			return objectValue(t, typ, attrs, nil)
+		}
+		t.Fatalf("%v can't hold a map", typ)
+	}
+	t.Fatalf("unsupported test value %T", v)
+	return tftypes.Value{}
+}
+
+// tfString, tfStrings, tfBool and tfNumber => tftypes values for test configs
+func tfString(s string) tftypes.Value { return tftypes.NewValue(tftypes.String, s) }
+
+func tfBool(b bool) tftypes.Value { return tftypes.NewValue(tftypes.Bool, b) }
+
+func tfNumber(n int64) tftypes.Value { return tftypes.NewValue(tftypes.Number, n) }
+
+func tfStrings(elemType func(tftypes.Type) tftypes.Type, ss ...string) tftypes.Value {
+	vals := make([]tftypes.Value, 0, len(ss))
+	for _, s := range ss {
+		vals = append(vals, tfString(s))
+	}
+	return tftypes.NewValue(elemType(tftypes.String), vals)
+}
+
+func listOf(t tftypes.Type) tftypes.Type { return tftypes.List{ElementType: t} }
+
+func setOf(t tftypes.Type) tftypes.Type { return tftypes.Set{ElementType: t} }
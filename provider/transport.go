@@ -0,0 +1,243 @@
+package provider
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"tailscale.com/tsnet"
+)
+
+// tailnetHeader => header carrying tailnet_name so a multi-tenant TACL can
+// scope the request to the right tailnet.
+const tailnetHeader = "Tailnet"
+
+// jsonAccept => Accept sent on every request unless the caller (or the
+// provider's `headers`) sets its own
+const jsonAccept = "application/json"
+
+// Connection pool defaults, sized for Terraform's default parallelism of 10
+// all talking to the one TACL host. Go's default of 2 idle conns per host
+// makes most parallel requests open (and later drop) a fresh connection.
+const (
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 10
+	defaultIdleConnTimeout     = 90 * time.Second
+)
+
+// Handshake timeouts, same as http.DefaultTransport's
+const (
+	defaultTLSHandshakeTimeout   = 10 * time.Second
+	defaultExpectContinueTimeout = 1 * time.Second
+)
+
+// poolOptions => connection pool and handshake tuning from the provider config
+type poolOptions struct {
+	maxIdleConnsPerHost   int
+	idleConnTimeout       time.Duration
+	tlsHandshakeTimeout   time.Duration
+	expectContinueTimeout time.Duration
+	disableCompression    bool
+}
+
+// newBaseTransport => http.DefaultTransport's settings (proxy from env,
+// dial timeouts, HTTP/2) with the pool and handshake timeouts tuned for TACL.
+// Unless disabled, the transport asks for gzip and decodes it transparently.
+func newBaseTransport(opts poolOptions) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConnsPerHost = opts.maxIdleConnsPerHost
+	t.MaxIdleConns = max(defaultMaxIdleConns, opts.maxIdleConnsPerHost)
+	t.IdleConnTimeout = opts.idleConnTimeout
+	t.TLSHandshakeTimeout = opts.tlsHandshakeTimeout
+	t.ExpectContinueTimeout = opts.expectContinueTimeout
+	t.DisableCompression = opts.disableCompression
+	return t
+}
+
+// gzipTransport => decodes gzip responses the base transport left encoded.
+// net/http only decodes gzip it asked for itself; an Accept-Encoding set via
+// `headers` (or a server that compresses unasked) would otherwise hand
+// compressed bytes to the JSON decoders.
+type gzipTransport struct {
+	base http.RoundTripper
+}
+
+func (t *gzipTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.base.RoundTrip(req)
+	if err != nil || res.Uncompressed || res.Body == nil ||
+		!strings.EqualFold(strings.TrimSpace(res.Header.Get("Content-Encoding")), "gzip") {
+		return res, err
+	}
+	zr, err := gzip.NewReader(res.Body)
+	if err != nil {
+		res.Body.Close()
+		return nil, fmt.Errorf("decode gzip response from %s: %w", req.URL, err)
+	}
+	res.Body = &gzipBody{zr: zr, body: res.Body}
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+	res.ContentLength = -1
+	res.Uncompressed = true
+	return res, nil
+}
+
+// gzipBody => decoded view of a gzip body that closes the underlying one
+type gzipBody struct {
+	zr   *gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Read(p []byte) (int, error) { return b.zr.Read(p) }
+
+func (b *gzipBody) Close() error {
+	b.zr.Close()
+	return b.body.Close()
+}
+
+// headerTransport => RoundTripper that sets fixed headers on every request
+// before handing it to the wrapped transport. Every TACL call goes through
+// the provider's http.Client, so this is the one place to add them.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.headers) > 0 {
+		// RoundTrippers must not modify the caller's request
+		req = req.Clone(req.Context())
+		for k, v := range t.headers {
+			if req.Header.Get(k) == "" {
+				req.Header.Set(k, v)
+			}
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+// wrapClient => copy of client whose transport adds headers. Never mutates
+// the client passed in (it may be http.DefaultClient).
+func wrapClient(client *http.Client, headers map[string]string) *http.Client {
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	wrapped := *client
+	wrapped.Transport = &headerTransport{base: base, headers: headers}
+	return &wrapped
+}
+
+// endpointProbeTimeout => cap on the configure-time reachability check
+const endpointProbeTimeout = 5 * time.Second
+
+// checkEndpointURL => endpoint must be an absolute http(s) URL with a host
+func checkEndpointURL(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid URL: %w", endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q must use http:// or https://, e.g. http://localhost:8080", endpoint)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host, e.g. http://localhost:8080", endpoint)
+	}
+	return nil
+}
+
+// joinPathPrefix => endpoint with prefix appended to its path, for TACL
+// mounted under a sub-path behind a shared reverse proxy. Slashes on either
+// side are normalized, so "http://host/" + "/tacl/" => "http://host/tacl".
+func joinPathPrefix(endpoint, prefix string) (string, error) {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return endpoint, nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("%q is not a valid URL: %w", endpoint, err)
+	}
+	if strings.ContainsAny(prefix, "?#") {
+		return "", fmt.Errorf("path_prefix %q must be a plain path without a query or fragment", prefix)
+	}
+	u.Path = strings.TrimRight(path.Join("/", u.Path, prefix), "/")
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+// checkEndpointHost => warning text if endpoint's host can't be found, or ""
+// if it resolves (or is an IP literal). With an ephemeral node the tailnet's
+// peers are checked first, since tsnet resolves MagicDNS names itself; other
+// hosts go to the system resolver.
+func checkEndpointHost(ctx context.Context, endpoint string, srv *tsnet.Server) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+	host := u.Hostname()
+	if host == "" {
+		return ""
+	}
+	if _, err := netip.ParseAddr(host); err == nil {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, endpointProbeTimeout)
+	defer cancel()
+
+	if srv != nil {
+		found, err := tailnetHasHost(ctx, srv, host)
+		if err != nil {
+			return fmt.Sprintf("Could not read the tailnet's peers to look up %q: %s.", host, err)
+		}
+		if found {
+			return ""
+		}
+	}
+	if _, err := net.DefaultResolver.LookupHost(ctx, host); err != nil {
+		if srv != nil {
+			return fmt.Sprintf("%q is neither a MagicDNS name of a peer on the tailnet nor resolvable via DNS: %s.", host, err)
+		}
+		msg := fmt.Sprintf("%q does not resolve: %s.", host, err)
+		if looksLikeMagicDNS(host) {
+			msg += " It looks like a MagicDNS name; set ephemeral = true (with client_id, client_secret and tags) " +
+				"to reach it over the tailnet, or run Terraform on a machine with Tailscale up."
+		}
+		return msg
+	}
+	return ""
+}
+
+// looksLikeMagicDNS => a single-label name or one under ts.net
+func looksLikeMagicDNS(host string) bool {
+	host = strings.TrimSuffix(host, ".")
+	return !strings.Contains(host, ".") || strings.HasSuffix(host, ".ts.net")
+}
+
+// probeEndpoint => HEAD <endpoint>/ over rt, the chain resources use minus
+// retries, so a gateway in front of TACL sees the configured headers and
+// auth. Any HTTP response counts as reachable, a 401/403 included; only
+// connection-level failures are reported.
+func probeEndpoint(ctx context.Context, rt http.RoundTripper, endpoint string) error {
+	ctx, cancel := context.WithTimeout(ctx, endpointProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, endpoint+"/", nil)
+	if err != nil {
+		return err
+	}
+	res, err := rt.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	return nil
+}
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func gzipped(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestProviderClient_GzipResponses(t *testing.T) {
+	const body = `{"name":"eng","members":["alice@example.com"]}`
+	tests := []struct {
+		name    string
+		config  map[string]tftypes.Value
+		gzip    string // "asked" => only if the request accepts gzip, "always", or "corrupt"
+		wantErr bool
+	}{
+		{name: "net/http asks for gzip", gzip: "asked"},
+		{name: "Accept-Encoding from headers", gzip: "asked", config: map[string]tftypes.Value{
+			"headers": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"Accept-Encoding": tfString("gzip"),
+			}),
+			"disable_compression": tfBool(true),
+		}},
+		{name: "compressed unasked", gzip: "always", config: map[string]tftypes.Value{
+			"disable_compression": tfBool(true),
+		}},
+		{name: "compression disabled", gzip: "asked", config: map[string]tftypes.Value{
+			"disable_compression": tfBool(true),
+		}},
+		{name: "corrupt gzip", gzip: "corrupt", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFakeTACL(t)
+			srv.handle("GET /groups/eng", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case tt.gzip == "corrupt":
+					w.Header().Set("Content-Encoding", "gzip")
+					_, _ = w.Write([]byte("not gzip"))
+				case tt.gzip == "always" || strings.Contains(r.Header.Get("Accept-Encoding"), "gzip"):
+					w.Header().Set("Content-Encoding", "gzip")
+					_, _ = w.Write(gzipped(t, body))
+				default:
+					_, _ = w.Write([]byte(body))
+				}
+			})
+			p := newTestProvider(t, srv, tt.config)
+
+			got, err := doSingleObjectReq(context.Background(), p.httpClient, http.MethodGet, srv.URL+"/groups/eng", nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %q, want a decode error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != body {
+				t.Fatalf("body = %q, want %q", got, body)
+			}
+		})
+	}
+}
+
+// TestProviderConfigure_ProbeThroughHeaders => the configure-time probe goes
+// out with the provider's `headers`, so a gateway that drops requests without
+// them doesn't trigger a false "unreachable" warning; a 401 still counts as
+// reachable.
+func TestProviderConfigure_ProbeThroughHeaders(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  map[string]interface{}
+		drop     bool // gateway closes the connection instead of answering 401
+		wantWarn bool
+	}{
+		{name: "headers reach the gateway", headers: map[string]interface{}{"X-Gateway-Token": "s3cret"}, drop: true},
+		{name: "gateway answers 401", headers: map[string]interface{}{"X-Gateway-Token": "wrong"}},
+		{name: "no headers, gateway drops", drop: true, wantWarn: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var probes []http.Header
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				probes = append(probes, r.Header.Clone())
+				mu.Unlock()
+				if r.Header.Get("X-Gateway-Token") == "s3cret" {
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+				if !tt.drop {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err == nil {
+					conn.Close()
+				}
+			}))
+			defer srv.Close()
+
+			ctx := context.Background()
+			p := New().(*taclProvider)
+			var schemaResp fwprovider.SchemaResponse
+			p.Schema(ctx, fwprovider.SchemaRequest{}, &schemaResp)
+			typ := schemaResp.Schema.Type().TerraformType(ctx)
+			vals := map[string]tftypes.Value{
+				"endpoint":    tfString(srv.URL),
+				"max_retries": tftypes.NewValue(tftypes.Number, 0),
+			}
+			if tt.headers != nil {
+				vals["headers"] = tfValue(t, tftypes.Map{ElementType: tftypes.String}, tt.headers)
+			}
+			resp := fwprovider.ConfigureResponse{}
+			p.Configure(ctx, fwprovider.ConfigureRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: objectValue(t, typ, vals, nil)},
+			}, &resp)
+			requireNoErrors(t, resp.Diagnostics)
+
+			warned := false
+			for _, d := range resp.Diagnostics.Warnings() {
+				warned = warned || d.Summary() == "TACL endpoint unreachable"
+			}
+			if warned != tt.wantWarn {
+				t.Fatalf("unreachable warning = %v, want %v (%v)", warned, tt.wantWarn, resp.Diagnostics)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if len(probes) == 0 {
+				t.Fatal("endpoint was never probed")
+			}
+			if want, _ := tt.headers["X-Gateway-Token"].(string); probes[0].Get("X-Gateway-Token") != want {
+				t.Errorf("probe sent X-Gateway-Token %q, want %q", probes[0].Get("X-Gateway-Token"), want)
+			}
+		})
+	}
+}
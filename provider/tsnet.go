@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	tsclient "github.com/tailscale/tailscale-client-go/v2"
+	"tailscale.com/tsnet"
+)
+
+// ephemeralKeyExpiry => the minted auth key only has to live long enough for
+// the node to join; the node itself goes away when the provider exits.
+const ephemeralKeyExpiry = 5 * time.Minute
+
+// ephemeralNode => a running tsnet node plus the temp dir holding its state
+// (including its node key), which must not outlive the provider process.
+type ephemeralNode struct {
+	srv *tsnet.Server
+	dir string
+}
+
+// close => leave the tailnet and delete the state dir
+func (n *ephemeralNode) close() {
+	n.srv.Close()
+	os.RemoveAll(n.dir)
+}
+
+// ephemeralNodes => every node started by this process. Providers have no
+// close hook of their own, so main calls Shutdown once the plugin server
+// stops serving.
+var ephemeralNodes struct {
+	sync.Mutex
+	nodes []*ephemeralNode
+}
+
+// Shutdown => close every ephemeral tailnet node this process started and
+// remove its state dir. Call it after providerserver.Serve returns.
+func Shutdown() {
+	ephemeralNodes.Lock()
+	defer ephemeralNodes.Unlock()
+	for _, n := range ephemeralNodes.nodes {
+		n.close()
+	}
+	ephemeralNodes.nodes = nil
+}
+
+// startEphemeralNode => mint a one-off ephemeral, pre-authorized auth key with
+// the OAuth client and join the tailnet as a userspace tsnet node, so the
+// provider can reach a TACL endpoint that's only on the tailnet (e.g. a
+// MagicDNS name). The node is registered for Shutdown; Tailscale also removes
+// it shortly after the process exits.
+func startEphemeralNode(ctx context.Context, clientID, clientSecret, tailnet string, tags []string) (*ephemeralNode, error) {
+	if tailnet == "" {
+		tailnet = "-" // the OAuth client's own tailnet
+	}
+	api := &tsclient.Client{
+		Tailnet: tailnet,
+		HTTP: tsclient.OAuthConfig{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Scopes:       []string{"auth_keys"},
+		}.HTTPClient(),
+	}
+
+	var keyReq tsclient.CreateKeyRequest
+	keyReq.Capabilities.Devices.Create.Ephemeral = true
+	keyReq.Capabilities.Devices.Create.Preauthorized = true
+	keyReq.Capabilities.Devices.Create.Tags = tags
+	keyReq.ExpirySeconds = int64(ephemeralKeyExpiry / time.Second)
+	keyReq.Description = "terraform-provider-tacl"
+
+	key, err := api.Keys().Create(ctx, keyReq)
+	if err != nil {
+		return nil, fmt.Errorf("mint ephemeral auth key: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "tacl-tsnet-")
+	if err != nil {
+		return nil, fmt.Errorf("create tsnet state dir: %w", err)
+	}
+
+	// The node logs for as long as it runs, long after Configure's ctx is
+	// done: keep ctx's logger but drop its cancellation.
+	logCtx := context.WithoutCancel(ctx)
+	srv := &tsnet.Server{
+		Hostname:  "tacl-terraform",
+		Dir:       dir,
+		AuthKey:   key.Key,
+		Ephemeral: true,
+		Logf: func(format string, args ...any) {
+			tflog.Trace(logCtx, fmt.Sprintf(format, args...))
+		},
+	}
+
+	tflog.Info(ctx, "Bringing up ephemeral Tailscale node", map[string]interface{}{
+		"hostname": srv.Hostname,
+		"tags":     tags,
+	})
+	if _, err := srv.Up(ctx); err != nil {
+		srv.Close()
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("join tailnet: %w", err)
+	}
+
+	node := &ephemeralNode{srv: srv, dir: dir}
+	ephemeralNodes.Lock()
+	ephemeralNodes.nodes = append(ephemeralNodes.nodes, node)
+	ephemeralNodes.Unlock()
+	return node, nil
+}
+
+// tailnetHasHost => whether host names a peer the ephemeral node can see:
+// its full MagicDNS name ("tacl.tail1234.ts.net") or the short name MagicDNS
+// expands ("tacl"). Matching is case-insensitive and ignores a trailing dot.
+func tailnetHasHost(ctx context.Context, srv *tsnet.Server, host string) (bool, error) {
+	lc, err := srv.LocalClient()
+	if err != nil {
+		return false, err
+	}
+	st, err := lc.Status(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	suffix := strings.ToLower(strings.TrimSuffix(st.MagicDNSSuffix, "."))
+	for _, peer := range st.Peer {
+		name := strings.ToLower(strings.TrimSuffix(peer.DNSName, "."))
+		if name == "" {
+			continue
+		}
+		if name == host || (suffix != "" && name == host+"."+suffix) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// splitTags => "tag:a, tag:b" => ["tag:a","tag:b"]
+func splitTags(s string) []string {
+	var out []string
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
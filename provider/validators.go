@@ -0,0 +1,601 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// aclProtoNames => protocol names Tailscale accepts in an ACL's proto, with
+// their IANA numbers.
+var aclProtoNames = map[string]int{
+	"icmp":     1,
+	"igmp":     2,
+	"ipv4":     4,
+	"ip-in-ip": 4,
+	"tcp":      6,
+	"egp":      8,
+	"igp":      9,
+	"udp":      17,
+	"gre":      47,
+	"esp":      50,
+	"ah":       51,
+	"sctp":     132,
+}
+
+// protoNumber => IANA number for a proto given as a name or a number
+func protoNumber(proto string) (int, bool) {
+	if n, ok := aclProtoNames[proto]; ok {
+		return n, true
+	}
+	if n, err := strconv.Atoi(proto); err == nil && n >= 0 && n <= 255 {
+		return n, true
+	}
+	return 0, false
+}
+
+// protosEqual => a and b name the same protocol, e.g. "tcp" and "6". Names
+// compare case-insensitively, so a server echoing "TCP" doesn't cause a diff.
+func protosEqual(a, b string) bool {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	if a == b {
+		return true
+	}
+	na, okA := protoNumber(a)
+	nb, okB := protoNumber(b)
+	return okA && okB && na == nb
+}
+
+// protoValidator => proto must be a known protocol name or an IANA protocol
+// number (0-255).
+type protoValidator struct{}
+
+var _ validator.String = protoValidator{}
+
+func (v protoValidator) Description(ctx context.Context) string {
+	return "must be one of " + strings.Join(sortedProtoNames(), ", ") + ", or a protocol number between 0 and 255"
+}
+
+func (v protoValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v protoValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	proto := req.ConfigValue.ValueString()
+	if _, ok := protoNumber(proto); ok {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(req.Path, "Invalid proto",
+		fmt.Sprintf("%q is not a valid protocol: %s.", proto, v.Description(ctx)))
+}
+
+func sortedProtoNames() []string {
+	names := make([]string, 0, len(aclProtoNames))
+	for name := range aclProtoNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// groupMembersValidator => members that reference another group must use the
+// "group:<name>" form. Catches typos like "Group:eng", "groups:eng" or a
+// bare "group:".
+type groupMembersValidator struct{}
+
+var _ validator.Set = groupMembersValidator{}
+
+func (v groupMembersValidator) Description(ctx context.Context) string {
+	return `group references must be written as "group:<name>"`
+}
+
+func (v groupMembersValidator) MarkdownDescription(ctx context.Context) string {
+	return "group references must be written as `group:<name>`"
+}
+
+func (v groupMembersValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for _, elem := range req.ConfigValue.Elements() {
+		s, ok := elem.(types.String)
+		if !ok || s.IsNull() || s.IsUnknown() {
+			continue
+		}
+		member := s.ValueString()
+		prefix, name, found := strings.Cut(member, ":")
+		if !found || strings.Contains(member, "@") || !strings.HasPrefix(strings.ToLower(prefix), "group") {
+			continue
+		}
+		if prefix == "group" && name != "" {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(req.Path.AtSetValue(s), "Invalid group member",
+			fmt.Sprintf("%q looks like a group reference, but %s.", member, v.Description(ctx)))
+	}
+}
+
+// jsonObjectValidator => value must be a JSON object (what jsonencode({...})
+// produces), so a typo fails at plan time instead of in Create.
+type jsonObjectValidator struct{}
+
+var _ validator.String = jsonObjectValidator{}
+
+func (v jsonObjectValidator) Description(ctx context.Context) string {
+	return "must be a JSON object"
+}
+
+func (v jsonObjectValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonObjectValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.ConfigValue.ValueString() == "" {
+		return
+	}
+	raw := req.ConfigValue.ValueString()
+	var obj map[string]interface{}
+	err := json.Unmarshal([]byte(raw), &obj)
+	if err == nil {
+		return
+	}
+
+	detail := err.Error()
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		line, col := jsonLineCol(raw, syntaxErr.Offset)
+		detail = fmt.Sprintf("line %d, column %d: %s", line, col, syntaxErr.Error())
+	case errors.As(err, &typeErr):
+		detail = fmt.Sprintf("expected a JSON object, got a JSON %s", typeErr.Value)
+	}
+	resp.Diagnostics.AddAttributeError(req.Path, "Invalid JSON", detail)
+}
+
+// jsonLineCol => 1-based line/column of byte offset in s. encoding/json
+// reports the offset just past the offending byte.
+func jsonLineCol(s string, offset int64) (int, int) {
+	if offset > int64(len(s)) {
+		offset = int64(len(s))
+	}
+	before := s[:max(offset-1, 0)]
+	line := strings.Count(before, "\n") + 1
+	col := len(before) - strings.LastIndex(before, "\n")
+	return line, col
+}
+
+// endpointValidator => endpoint must parse as a URL with a scheme, so
+// "localhost:8080" or "tacl.example.com" is caught before any request.
+type endpointValidator struct{}
+
+var _ validator.String = endpointValidator{}
+
+func (v endpointValidator) Description(ctx context.Context) string {
+	return "must be a URL with a scheme, e.g. http://localhost:8080"
+}
+
+func (v endpointValidator) MarkdownDescription(ctx context.Context) string {
+	return "must be a URL with a scheme, e.g. `http://localhost:8080`"
+}
+
+func (v endpointValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	raw := req.ConfigValue.ValueString()
+	u, err := url.Parse(raw)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid endpoint",
+			fmt.Sprintf("%q is not a valid URL: %s.", raw, err))
+		return
+	}
+	if u.Scheme == "" || u.Opaque != "" {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid endpoint",
+			fmt.Sprintf("%q has no scheme: the endpoint %s.", raw, v.Description(ctx)))
+	}
+}
+
+// hujsonValidator => value must parse as HuJSON (JSON with comments and
+// trailing commas), like a Tailscale policy file.
+type hujsonValidator struct{}
+
+var _ validator.String = hujsonValidator{}
+
+func (v hujsonValidator) Description(ctx context.Context) string {
+	return "must be valid HuJSON"
+}
+
+func (v hujsonValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v hujsonValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := decodeHuJSON(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid policy document", err.Error())
+	}
+}
+
+// knownNodeAttrs => node attributes Tailscale documents. Not exhaustive, so
+// anything else is only a warning.
+var knownNodeAttrs = map[string]bool{
+	"funnel":                           true,
+	"mullvad":                          true,
+	"drive:share":                      true,
+	"drive:access":                     true,
+	"magicdns-aaaa":                    true,
+	"only-tcp-443":                     true,
+	"randomize-client-port":            true,
+	"disable-captive-portal-detection": true,
+	"debug-disable-upnp":               true,
+}
+
+// knownNodeAttrPrefixes => attributes that take a value after the colon
+var knownNodeAttrPrefixes = []string{"nextdns:"}
+
+// nodeAttrsValidator => every attr element must be non-empty, free of
+// whitespace and, for "prefix:value" forms, have a value. Unrecognized
+// attributes get a warning since Tailscale adds new ones regularly.
+type nodeAttrsValidator struct{}
+
+var _ validator.List = nodeAttrsValidator{}
+
+func (v nodeAttrsValidator) Description(ctx context.Context) string {
+	return "each attribute must be a non-empty name like 'funnel' or 'nextdns:<profile>' without whitespace"
+}
+
+func (v nodeAttrsValidator) MarkdownDescription(ctx context.Context) string {
+	return "each attribute must be a non-empty name like `funnel` or `nextdns:<profile>` without whitespace"
+}
+
+func (v nodeAttrsValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for i, elem := range req.ConfigValue.Elements() {
+		s, ok := elem.(types.String)
+		if !ok || s.IsNull() || s.IsUnknown() {
+			continue
+		}
+		p := req.Path.AtListIndex(i)
+		a := s.ValueString()
+		switch {
+		case strings.TrimSpace(a) == "":
+			resp.Diagnostics.AddAttributeError(p, "Invalid node attribute", "Node attributes can't be empty.")
+		case strings.IndexFunc(a, unicode.IsSpace) >= 0:
+			resp.Diagnostics.AddAttributeError(p, "Invalid node attribute",
+				fmt.Sprintf("%q contains whitespace; %s.", a, v.Description(ctx)))
+		case strings.HasPrefix(a, ":") || strings.HasSuffix(a, ":"):
+			resp.Diagnostics.AddAttributeError(p, "Invalid node attribute",
+				fmt.Sprintf("%q is missing a name or value around ':'; %s.", a, v.Description(ctx)))
+		case !isKnownNodeAttr(a):
+			resp.Diagnostics.AddAttributeWarning(p, "Unrecognized node attribute",
+				fmt.Sprintf("%q isn't a node attribute this provider knows about. It's sent as-is; check for typos.", a))
+		}
+	}
+}
+
+func isKnownNodeAttr(a string) bool {
+	if knownNodeAttrs[a] {
+		return true
+	}
+	for _, prefix := range knownNodeAttrPrefixes {
+		if strings.HasPrefix(a, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// postureRefsValidator => src_posture elements must be "posture:<name>",
+// the form Tailscale uses to reference a posture from an ACL.
+type postureRefsValidator struct{}
+
+var _ validator.List = postureRefsValidator{}
+
+func (v postureRefsValidator) Description(ctx context.Context) string {
+	return `posture references must be written as "posture:<name>"`
+}
+
+func (v postureRefsValidator) MarkdownDescription(ctx context.Context) string {
+	return "posture references must be written as `posture:<name>`"
+}
+
+func (v postureRefsValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for i, elem := range req.ConfigValue.Elements() {
+		s, ok := elem.(types.String)
+		if !ok || s.IsNull() || s.IsUnknown() {
+			continue
+		}
+		if name, found := strings.CutPrefix(s.ValueString(), "posture:"); found && name != "" && strings.IndexFunc(name, unicode.IsSpace) < 0 {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(req.Path.AtListIndex(i), "Invalid posture reference",
+			fmt.Sprintf("%q is not a valid posture reference; %s.", s.ValueString(), v.Description(ctx)))
+	}
+}
+
+// ipAddrValidator => value must be a plain address of the given family (4 or
+// 6), so a v6 address in the ipv4 slot (or the reverse) fails at plan time.
+// Empty and "none" (Tailscale's "don't use this family") are allowed.
+type ipAddrValidator struct {
+	family int
+}
+
+var _ validator.String = ipAddrValidator{}
+
+func (v ipAddrValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("must be an IPv%d address, 'none', or empty", v.family)
+}
+
+func (v ipAddrValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("must be an IPv%d address, `none`, or empty", v.family)
+}
+
+func (v ipAddrValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	s := req.ConfigValue.ValueString()
+	if s == "" || s == "none" {
+		return
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid IP address",
+			fmt.Sprintf("%q is not an IP address; it %s.", s, v.Description(ctx)))
+		return
+	}
+	switch {
+	case v.family == 4 && !addr.Is4():
+		resp.Diagnostics.AddAttributeError(req.Path, "Wrong IP address family",
+			fmt.Sprintf("%q is not an IPv4 address. Did you mean to put it in ipv6?", s))
+	case v.family == 6 && (!addr.Is6() || addr.Is4In6()):
+		resp.Diagnostics.AddAttributeError(req.Path, "Wrong IP address family",
+			fmt.Sprintf("%q is not an IPv6 address. Did you mean to put it in ipv4?", s))
+	case addr.Zone() != "":
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid IP address",
+			fmt.Sprintf("%q has a zone (%%%s); DERP nodes need a routable address.", s, addr.Zone()))
+	}
+}
+
+// oneCGNATRouteModes => non-CIDR values Tailscale accepts for OneCGNATRoute
+var oneCGNATRouteModes = map[string]bool{
+	"mac-always": true,
+	"mac-never":  true,
+}
+
+// oneCGNATRouteValidator => empty (unset), one of Tailscale's mode keywords,
+// or a valid CIDR. Catches typos at plan time.
+type oneCGNATRouteValidator struct{}
+
+var _ validator.String = oneCGNATRouteValidator{}
+
+func (v oneCGNATRouteValidator) Description(ctx context.Context) string {
+	return "must be empty, 'mac-always', 'mac-never', or a CIDR like '100.64.0.0/10'"
+}
+
+func (v oneCGNATRouteValidator) MarkdownDescription(ctx context.Context) string {
+	return "must be empty, `mac-always`, `mac-never`, or a CIDR like `100.64.0.0/10`"
+}
+
+func (v oneCGNATRouteValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	s := req.ConfigValue.ValueString()
+	if s == "" || oneCGNATRouteModes[s] {
+		return
+	}
+	if _, err := netip.ParsePrefix(s); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid one_cgnat_route",
+			fmt.Sprintf("%q is not valid: %s. It %s.", s, err, v.Description(ctx)))
+	}
+}
+
+// nonEmptyListValidator => a set list must have at least one element. TACL
+// rejects empty src/dst/users, so catch it at plan time with the path.
+type nonEmptyListValidator struct{}
+
+var _ validator.List = nonEmptyListValidator{}
+
+func (v nonEmptyListValidator) Description(ctx context.Context) string {
+	return "list must contain at least one element"
+}
+
+func (v nonEmptyListValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v nonEmptyListValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if len(req.ConfigValue.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Empty list",
+			fmt.Sprintf("%s can't be empty; TACL rejects it.", req.Path))
+	}
+}
+
+// aclDstPortsValidator => the port part of each dst ("10.1.2.3/32:22",
+// "tag:web:80,443", "host:8000-8100", "[fd7a::1]:22", "*:*") must parse. Only a suffix made of
+// digits, '*', ',' and '-' is treated as ports, so "tag:prod" (a name, not a
+// port) passes and TACL stays the judge of whether it needs one.
+type aclDstPortsValidator struct{}
+
+var _ validator.List = aclDstPortsValidator{}
+
+func (v aclDstPortsValidator) Description(ctx context.Context) string {
+	return "ports after the last ':' must be '*' or comma-separated ports/ranges (e.g. '22', '80,443', '8000-8100') within 0-65535; IPv6 addresses must be bracketed, e.g. '[fd7a::1]:22'"
+}
+
+func (v aclDstPortsValidator) MarkdownDescription(ctx context.Context) string {
+	return "ports after the last `:` must be `*` or comma-separated ports/ranges (e.g. `22`, `80,443`, `8000-8100`) within 0-65535; IPv6 addresses must be bracketed, e.g. `[fd7a::1]:22`"
+}
+
+func (v aclDstPortsValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for i, elem := range req.ConfigValue.Elements() {
+		s, ok := elem.(types.String)
+		if !ok || s.IsNull() || s.IsUnknown() {
+			continue
+		}
+		if err := checkDstPorts(s.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path.AtListIndex(i), "Invalid destination port",
+				fmt.Sprintf("%q: %s. The %s.", s.ValueString(), err, v.Description(ctx)))
+		}
+	}
+}
+
+// checkDstPorts => error if dst ends in a malformed port spec; nil when there's
+// no colon or the suffix isn't port-shaped. An IPv6 address or prefix must be
+// bracketed ("[fd7a::1]:22"): unbracketed, "fd7a::1" can't be told apart from
+// host "fd7a:" on port 1.
+func checkDstPorts(dst string) error {
+	if strings.HasPrefix(dst, "[") {
+		end := strings.IndexByte(dst, ']')
+		if end < 0 {
+			return errors.New("missing ']' after the IPv6 address")
+		}
+		if !isIPv6(dst[1:end]) {
+			return fmt.Errorf("%q in brackets is not an IPv6 address or prefix", dst[1:end])
+		}
+		rest := dst[end+1:]
+		if rest == "" {
+			return nil
+		}
+		ports, ok := strings.CutPrefix(rest, ":")
+		if !ok {
+			return fmt.Errorf("expected ':' after ']', got %q", rest)
+		}
+		if ports != "" && strings.Trim(ports, "0123456789*,-") != "" {
+			return fmt.Errorf("%q is not a port", ports)
+		}
+		return checkPorts(ports)
+	}
+
+	i := strings.LastIndexByte(dst, ':')
+	if i < 0 {
+		return nil
+	}
+	if isIPv6(dst) || isIPv6(dst[:i]) {
+		return errors.New(`IPv6 destinations must be bracketed, e.g. "[fd7a::1]:22"`)
+	}
+	ports := dst[i+1:]
+	if ports != "" && strings.Trim(ports, "0123456789*,-") != "" {
+		return nil
+	}
+	return checkPorts(ports)
+}
+
+// checkPorts => error unless ports is '*' or comma-separated ports/ranges
+func checkPorts(ports string) error {
+	if ports == "" {
+		return errors.New("missing port after ':'")
+	}
+	if ports == "*" {
+		return nil
+	}
+	for _, part := range strings.Split(ports, ",") {
+		lo, hi, isRange := strings.Cut(part, "-")
+		first, err := parsePort(lo)
+		if err != nil {
+			return err
+		}
+		if !isRange {
+			continue
+		}
+		last, err := parsePort(hi)
+		if err != nil {
+			return err
+		}
+		if first > last {
+			return fmt.Errorf("port range %q is reversed", part)
+		}
+	}
+	return nil
+}
+
+// isIPv6 => s is an IPv6 address or prefix
+func isIPv6(s string) bool {
+	if addr, err := netip.ParseAddr(s); err == nil {
+		return addr.Is6()
+	}
+	prefix, err := netip.ParsePrefix(s)
+	return err == nil && prefix.Addr().Is6()
+}
+
+func parsePort(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > 65535 {
+		return 0, fmt.Errorf("%q is not a port", s)
+	}
+	return n, nil
+}
+
+// sshDstValidator => each SSH dst must be a non-empty name without
+// whitespace, e.g. "tag:prod", "autogroup:self" or "host:22". A port-shaped
+// suffix is checked the same way as for ACL dst (see checkDstPorts).
+type sshDstValidator struct{}
+
+var _ validator.List = sshDstValidator{}
+
+func (v sshDstValidator) Description(ctx context.Context) string {
+	return "each destination must be a non-empty tag, autogroup, host or IP without spaces, optionally with ':port'; IPv6 addresses must be bracketed, e.g. '[fd7a::1]:22'"
+}
+
+func (v sshDstValidator) MarkdownDescription(ctx context.Context) string {
+	return "each destination must be a non-empty tag, autogroup, host or IP without spaces, optionally with `:port`; IPv6 addresses must be bracketed, e.g. `[fd7a::1]:22`"
+}
+
+func (v sshDstValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for i, elem := range req.ConfigValue.Elements() {
+		s, ok := elem.(types.String)
+		if !ok || s.IsNull() || s.IsUnknown() {
+			continue
+		}
+		if err := checkSSHDst(s.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path.AtListIndex(i), "Invalid SSH destination",
+				fmt.Sprintf("%q: %s. The %s.", s.ValueString(), err, v.Description(ctx)))
+		}
+	}
+}
+
+// checkSSHDst => error for an empty or space-containing dst, or a malformed port
+func checkSSHDst(dst string) error {
+	if strings.TrimSpace(dst) == "" {
+		return errors.New("empty destination")
+	}
+	if strings.ContainsFunc(dst, unicode.IsSpace) {
+		return errors.New("contains whitespace")
+	}
+	if strings.HasPrefix(dst, ":") {
+		return errors.New("missing host before ':'")
+	}
+	return checkDstPorts(dst)
+}
@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// runStringValidator => diagnostics of v for value at path "attr"
+func runStringValidator(v validator.String, value types.String) validator.StringResponse {
+	var resp validator.StringResponse
+	v.ValidateString(context.Background(), validator.StringRequest{Path: path.Root("attr"), ConfigValue: value}, &resp)
+	return resp
+}
+
+func TestIPAddrValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		family  int
+		value   types.String
+		wantErr string // summary, "" => valid
+	}{
+		{"v4 in ipv4", 4, types.StringValue("192.0.2.10"), ""},
+		{"v6 in ipv6", 6, types.StringValue("2001:db8::10"), ""},
+		{"v6 in ipv4", 4, types.StringValue("2001:db8::10"), "Wrong IP address family"},
+		{"v4 in ipv6", 6, types.StringValue("192.0.2.10"), "Wrong IP address family"},
+		{"v4-mapped v6 in ipv6", 6, types.StringValue("::ffff:192.0.2.10"), "Wrong IP address family"},
+		{"v6 with zone", 6, types.StringValue("fe80::1%eth0"), "Invalid IP address"},
+		{"hostname in ipv4", 4, types.StringValue("derp.example.com"), "Invalid IP address"},
+		{"cidr in ipv6", 6, types.StringValue("2001:db8::/64"), "Invalid IP address"},
+		{"none in ipv4", 4, types.StringValue("none"), ""},
+		{"none in ipv6", 6, types.StringValue("none"), ""},
+		{"empty ipv4", 4, types.StringValue(""), ""},
+		{"empty ipv6", 6, types.StringValue(""), ""},
+		{"null ipv4", 4, types.StringNull(), ""},
+		{"null ipv6", 6, types.StringNull(), ""},
+		{"unknown ipv4", 4, types.StringUnknown(), ""},
+		{"unknown ipv6", 6, types.StringUnknown(), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := runStringValidator(ipAddrValidator{family: tt.family}, tt.value)
+			var got string
+			if resp.Diagnostics.HasError() {
+				got = resp.Diagnostics.Errors()[0].Summary()
+			}
+			if got != tt.wantErr {
+				t.Fatalf("error = %q, want %q (%v)", got, tt.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestNonEmptyListValidator_ValidateConfig(t *testing.T) {
+	unknown := tftypes.NewValue(listOf(tftypes.String), tftypes.UnknownValue)
+	null := tftypes.NewValue(listOf(tftypes.String), nil)
+	acl := map[string]tftypes.Value{
+		"action": tfString("accept"),
+		"src":    tfStrings(listOf, "group:eng"),
+		"dst":    tfStrings(listOf, "tag:web:443"),
+	}
+	ssh := map[string]tftypes.Value{
+		"action": tfString("accept"),
+		"src":    tfStrings(listOf, "group:eng"),
+		"dst":    tfStrings(listOf, "tag:prod"),
+		"users":  tfStrings(listOf, "root"),
+	}
+	with := func(base map[string]tftypes.Value, attr string, v tftypes.Value) map[string]tftypes.Value {
+		out := map[string]tftypes.Value{attr: v}
+		for k, bv := range base {
+			if k != attr {
+				out[k] = bv
+			}
+		}
+		return out
+	}
+	tests := []struct {
+		name     string
+		typeName string
+		r        resource.Resource
+		vals     map[string]tftypes.Value
+		wantErr  bool
+	}{
+		{"acl valid", "tacl_acl", NewACLResource(), acl, false},
+		{"acl empty src", "tacl_acl", NewACLResource(), with(acl, "src", tfStrings(listOf)), true},
+		{"acl empty dst", "tacl_acl", NewACLResource(), with(acl, "dst", tfStrings(listOf)), true},
+		{"acl unknown src", "tacl_acl", NewACLResource(), with(acl, "src", unknown), false},
+		{"acl unknown dst", "tacl_acl", NewACLResource(), with(acl, "dst", unknown), false},
+		{"ssh valid", "tacl_ssh", NewSSHResource(), ssh, false},
+		{"ssh empty users", "tacl_ssh", NewSSHResource(), with(ssh, "users", tfStrings(listOf)), true},
+		{"ssh unknown users", "tacl_ssh", NewSSHResource(), with(ssh, "users", unknown), false},
+		{"ssh empty dst", "tacl_ssh", NewSSHResource(), with(ssh, "dst", tfStrings(listOf)), true},
+		{"ssh empty accept_env", "tacl_ssh", NewSSHResource(), with(ssh, "accept_env", tfStrings(listOf)), false},
+		{"ssh null accept_env", "tacl_ssh", NewSSHResource(), with(ssh, "accept_env", null), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var empty []string
+			var errs []string
+			for _, d := range validateResourceConfig(t, tt.typeName, tt.r, tt.vals) {
+				if d.Severity != tfprotov6.DiagnosticSeverityError {
+					continue
+				}
+				errs = append(errs, d.Summary+": "+d.Detail)
+				if d.Summary == "Empty list" {
+					empty = append(empty, d.Detail)
+				}
+			}
+			if (len(empty) > 0) != tt.wantErr || !tt.wantErr && len(errs) > 0 {
+				t.Fatalf("empty list errors = %v, want %v (all errors: %v)", empty, tt.wantErr, errs)
+			}
+		})
+	}
+
+	// null passes the validator itself; whether the attribute may be
+	// omitted is up to the schema and ValidateConfig
+	for _, v := range []types.List{types.ListNull(types.StringType), types.ListUnknown(types.StringType)} {
+		var resp validator.ListResponse
+		nonEmptyListValidator{}.ValidateList(context.Background(), validator.ListRequest{Path: path.Root("src"), ConfigValue: v}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Errorf("%v: unexpected errors %v", v, resp.Diagnostics)
+		}
+	}
+}
+
+func TestProtoNormalization(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		equal bool
+	}{
+		{"tcp", "6", true},
+		{"6", "tcp", true},
+		{"udp", "17", true},
+		{"icmp", "1", true},
+		{"TCP", "tcp", true},
+		{"Udp", "17", true},
+		{"ipv4", "ip-in-ip", true},
+		{"tcp", "udp", false},
+		{"tcp", "17", false},
+		{"6", "06", true},
+		{"foo", "foo", true},
+		{"foo", "6", false},
+		{"foo", "bar", false},
+		{"", "tcp", false},
+		{"256", "0", false},
+	}
+	for _, tt := range tests {
+		if got := protosEqual(tt.a, tt.b); got != tt.equal {
+			t.Errorf("protosEqual(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.equal)
+		}
+	}
+
+	priorTests := []struct {
+		name   string
+		server string
+		prior  types.String
+		want   types.String
+	}{
+		{"number for name", "6", types.StringValue("tcp"), types.StringValue("tcp")},
+		{"name for number", "tcp", types.StringValue("6"), types.StringValue("6")},
+		{"icmp", "icmp", types.StringValue("1"), types.StringValue("1")},
+		{"different case", "UDP", types.StringValue("udp"), types.StringValue("udp")},
+		{"different protocol", "udp", types.StringValue("6"), types.StringValue("udp")},
+		{"unknown name", "foo", types.StringValue("6"), types.StringValue("foo")},
+		{"no prior", "tcp", types.StringNull(), types.StringValue("tcp")},
+		{"unknown prior", "tcp", types.StringUnknown(), types.StringValue("tcp")},
+		{"server omits proto", "", types.StringValue("tcp"), types.StringNull()},
+	}
+	for _, tt := range priorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := protoOrPrior(tt.server, tt.prior); !got.Equal(tt.want) {
+				t.Fatalf("protoOrPrior(%q, %v) = %v, want %v", tt.server, tt.prior, got, tt.want)
+			}
+		})
+	}
+
+	for name, n := range map[string]string{"tcp": "6", "udp": "17", "icmp": "1"} {
+		resp := runStringValidator(protoValidator{}, types.StringValue(name))
+		resp.Diagnostics.Append(runStringValidator(protoValidator{}, types.StringValue(n)).Diagnostics...)
+		if resp.Diagnostics.HasError() {
+			t.Errorf("%s/%s rejected: %v", name, n, resp.Diagnostics)
+		}
+	}
+	for _, bad := range []string{"foo", "256", "-1"} {
+		if !runStringValidator(protoValidator{}, types.StringValue(bad)).Diagnostics.HasError() {
+			t.Errorf("proto %q accepted", bad)
+		}
+	}
+}
+
+// runListValidator => diagnostics of v for a list of strings at path "attr"
+func runListValidator(v validator.List, elems ...string) validator.ListResponse {
+	vals := make([]attr.Value, len(elems))
+	for i, e := range elems {
+		vals[i] = types.StringValue(e)
+	}
+	var resp validator.ListResponse
+	v.ValidateList(context.Background(), validator.ListRequest{
+		Path:        path.Root("attr"),
+		ConfigValue: types.ListValueMust(types.StringType, vals),
+	}, &resp)
+	return resp
+}
+
+func TestSSHDstValidator(t *testing.T) {
+	tests := []struct {
+		dst     string
+		wantErr bool
+	}{
+		{"tag:prod", false},
+		{"autogroup:self", false},
+		{"host:22", false},
+		{"100.64.0.1", false},
+		{"host:22,2222", false},
+		{"", true},
+		{"   ", true},
+		{"tag:prod ", true},
+		{"tag: prod", true},
+		{"tag:prod\t", true},
+		{":22", true},
+		{"host:", true},
+		{"host:99999", true},
+		{"host:22-", true},
+		{"host:2222-22", true},
+		{"host:22,,23", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dst, func(t *testing.T) {
+			resp := runListValidator(sshDstValidator{}, tt.dst)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("dst %q: errors = %v, want error %v", tt.dst, resp.Diagnostics.Errors(), tt.wantErr)
+			}
+		})
+	}
+
+	// only the bad element is reported, at its index
+	resp := runListValidator(sshDstValidator{}, "tag:prod", "host:0-99999")
+	if errs := resp.Diagnostics.Errors(); len(errs) != 1 || !strings.Contains(errs[0].Detail(), "host:0-99999") {
+		t.Fatalf("errors = %v, want one for the second element", errs)
+	}
+}
+
+func TestCheckDstPorts_IPv6(t *testing.T) {
+	tests := []struct {
+		dst     string
+		wantErr string // "" => valid
+	}{
+		{"[fd7a::1]:22", ""},
+		{"[fd7a::1]:*", ""},
+		{"[fd7a::1]:80,443", ""},
+		{"[2001:db8::]/32:22", "expected ':' after ']'"},
+		{"[2001:db8::/32]:8000-8100", ""},
+		{"[fd7a::1]", ""},
+		{"[::ffff:100.64.0.1]:22", ""},
+		{"2001:db8::", "must be bracketed"},
+		{"fd7a::1", "must be bracketed"},
+		{"fd7a::1:22", "must be bracketed"},
+		{"fd7a::1:*", "must be bracketed"},
+		{"2001:db8::/32", "must be bracketed"},
+		{"2001:db8::/32:22", "must be bracketed"},
+		{"[fd7a::1", "missing ']'"},
+		{"[100.64.0.1]:22", "not an IPv6 address"},
+		{"[tag:prod]:22", "not an IPv6 address"},
+		{"[fd7a::1]22", "expected ':' after ']'"},
+		{"[fd7a::1]:", "missing port"},
+		{"[fd7a::1]:ssh", "is not a port"},
+		{"[fd7a::1]:99999", "is not a port"},
+		{"100.64.0.1:22", ""},
+		{"tag:web:80,443", ""},
+		{"*:*", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dst, func(t *testing.T) {
+			err := checkDstPorts(tt.dst)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkDstPorts(%q) = %v, want nil", tt.dst, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkDstPorts(%q) = %v, want error containing %q", tt.dst, err, tt.wantErr)
+			}
+		})
+	}
+
+	// both validators surface the bracket hint
+	for name, v := range map[string]validator.List{"acl": aclDstPortsValidator{}, "ssh": sshDstValidator{}} {
+		resp := runListValidator(v, "fd7a::1")
+		if errs := resp.Diagnostics.Errors(); len(errs) != 1 || !strings.Contains(errs[0].Detail(), "[fd7a::1]:22") {
+			t.Errorf("%s: errors = %v, want one naming the bracketed form", name, errs)
+		}
+	}
+}